@@ -1,11 +1,13 @@
 package main
 
 import (
+	b "aura/src/builtins"
 	e "aura/src/evaluator"
 	l "aura/src/lexer"
 	obj "aura/src/object"
 	p "aura/src/parser"
 	"aura/src/repl"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -35,6 +37,18 @@ func validatePath(path string) error {
 
 // read the file in the path and evaluate the file
 func ReadFile(path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Println("No se pudo leer el archivo")
+		return
+	}
+
+	defer file.Close()
+	evaluateSource(l.NewReaderLexer(file))
+}
+
+// parse and evaluate the source code read by the lexer
+func evaluateSource(lexer *l.Lexer) {
 	defer func() {
 		// we handle a posible panic in the parser
 		// and the evaluator
@@ -44,17 +58,6 @@ func ReadFile(path string) {
 		}
 	}()
 
-	source, err := os.ReadFile(path)
-	if err != nil {
-		fmt.Println("No se pudo leer el archivo")
-		return
-	}
-
-	if len(source) == 0 {
-		return
-	}
-
-	lexer := l.NewLexer(string(source))
 	parser := p.NewParser(lexer)
 	env := obj.NewEnviroment(nil)
 	program := parser.ParseProgam()
@@ -74,12 +77,37 @@ func ReadFile(path string) {
 }
 
 func main() {
-	if len(os.Args) < 2 {
+	sandbox := flag.String("raiz", "", "directorio al que el modulo archivos tiene acceso")
+	language := flag.String("idioma", "es", "idioma de las palabras reservadas: es, en o pt")
+	flag.Parse()
+
+	if err := l.SetDefaultLanguage(*language); err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	if *sandbox != "" {
+		if err := b.SetSandboxRoot(*sandbox); err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+	}
+
+	if flag.NArg() < 1 {
 		repl.StartRpl()
 		return
 	}
 
-	filePath := os.Args[1]
+	// the arguments after the file are given to the script
+	filePath := flag.Arg(0)
+	b.SetScriptArgs(flag.Args()[1:])
+
+	// a dash reads the program from the standard input
+	if filePath == "-" {
+		evaluateSource(l.NewReaderLexer(os.Stdin))
+		return
+	}
+
 	if err := validatePath(filePath); err != nil {
 		fmt.Println(err.Error())
 		return
@@ -85,7 +85,7 @@ func NewLetStatement(token *l.Token, name *Identifier, value Expression) *LetSta
 func (l LetStatement) stmtNode() {}
 
 func (l LetStatement) Str() string {
-	return fmt.Sprintf("var %s = %s;", l.Name.Str(), l.Value.Str())
+	return fmt.Sprintf("%s %s = %s;", l.TokenLiteral(), l.Name.Str(), l.Value.Str())
 }
 
 // Represents a return statement
@@ -201,6 +201,7 @@ type Function struct {
 	Name       *Identifier   // represents the function name
 	Parameters []*Identifier // represents the parameters of the function
 	Body       *Block        // represents the function body
+	Doc        string        // represents the doc comment of the function
 }
 
 // create a new function instance
@@ -342,6 +343,45 @@ func (c *CallList) Str() string {
 	return fmt.Sprintf("%s[%s]", c.ListIdent.Str(), c.Index.Str())
 }
 
+// represents a slice of a list or a string like:
+//		lista[inicio:fin:paso]
+// the parts that are not given are nil
+type Slice struct {
+	BaseNode         // Extends base node struct
+	Start Expression // represents the first index of the slice
+	End   Expression // represents the index where the slice ends, not included
+	Step  Expression // represents the distance between the indexes
+}
+
+// generates a new Slice instance
+func NewSlice(token *l.Token, start Expression, end Expression, step Expression) *Slice {
+	return &Slice{
+		BaseNode: BaseNode{token},
+		Start:    start,
+		End:      end,
+		Step:     step,
+	}
+}
+
+func (s *Slice) expressNode() {}
+func (s *Slice) Str() string {
+	parts := make([]string, 0, 3)
+	for _, part := range []Expression{s.Start, s.End, s.Step} {
+		if part == nil {
+			parts = append(parts, "")
+			continue
+		}
+
+		parts = append(parts, part.Str())
+	}
+
+	if s.Step == nil {
+		parts = parts[:2]
+	}
+
+	return strings.Join(parts, ":")
+}
+
 // Represents a HashMap expression
 type MapExpression struct {
 	BaseNode             // Extends base node struct
@@ -367,12 +407,77 @@ func (m *MapExpression) Str() string {
 	return fmt.Sprintf("mapa{%s}", buf.String())
 }
 
+// Represents a set expression like:
+//		conjunto{1, 2, 3}
+type SetExpression struct {
+	BaseNode              // Extends base node struct
+	Values   []Expression // represents the values inside the set
+}
+
+// generates a new SetExpression instance
+func NewSetExpression(token *l.Token, values ...Expression) *SetExpression {
+	return &SetExpression{BaseNode: BaseNode{token}, Values: values}
+}
+
+func (s *SetExpression) expressNode() {}
+
+func (s *SetExpression) Str() string {
+	values := make([]string, 0, len(s.Values))
+	for _, value := range s.Values {
+		values = append(values, value.Str())
+	}
+
+	return fmt.Sprintf("conjunto{%s}", strings.Join(values, ", "))
+}
+
+// represents a list, map or set comprehension like:
+//		lista[x * 2 por x en datos si x > 0]
+//		mapa{k => v por k, v en pares}
+//		conjunto{x % 3 por x en datos}
+type Comprehension struct {
+	BaseNode                   // Extends base node struct
+	Value     Expression       // represents each value, a key value for maps
+	Range     *RangeExpression // represents the variables and the iterable
+	Condition Expression       // represents the optional filter
+}
+
+// generates a new Comprehension instance
+func NewComprehension(token *l.Token, value Expression, rangeExp *RangeExpression, condition Expression) *Comprehension {
+	return &Comprehension{
+		BaseNode:  BaseNode{token},
+		Value:     value,
+		Range:     rangeExp,
+		Condition: condition,
+	}
+}
+
+func (c *Comprehension) expressNode() {}
+
+func (c *Comprehension) Str() string {
+	str := fmt.Sprintf("%s por %s", c.Value.Str(), c.Range.Str())
+	if c.Condition != nil {
+		str = fmt.Sprintf("%s si %s", str, c.Condition.Str())
+	}
+
+	switch c.Token.Token_type {
+	case l.MAP:
+		return fmt.Sprintf("mapa{%s}", str)
+
+	case l.SET:
+		return fmt.Sprintf("conjunto{%s}", str)
+
+	default:
+		return fmt.Sprintf("lista[%s]", str)
+	}
+}
+
 // Represents a class statement
 type ClassStatement struct {
 	BaseNode                   // extends base node
 	Name     *Identifier       // represents the class name
 	Params   []*Identifier     // represents the constructor params
 	Methods  []*ClassMethodExp // represents all the methods in the class
+	Doc      string            // represents the doc comment of the class
 }
 
 // generates a new class statement instance
@@ -435,6 +540,7 @@ type ArrowFunc struct {
 	BaseNode               // extends base node struct
 	Params   []*Identifier // represents the parameters of the function
 	Body     *Block        // represents the body of the function
+	Doc      string        // represents the doc comment of the assignment
 }
 
 // generates a new arrow function instance
@@ -3,6 +3,7 @@ package ast
 import (
 	l "aura/src/lexer"
 	"fmt"
+	"strings"
 )
 
 // Represents a infix expression like 5 + 5:
@@ -54,6 +55,29 @@ func (r *RangeExpression) Str() string {
 	return fmt.Sprintf("%s en %s", r.Variable.Str(), r.Range.Str())
 }
 
+// represents several variables that receive the values of a list like:
+//		por(llave, valor en pares)
+type Destructuring struct {
+	BaseNode                // Extends base node struct
+	Variables []*Identifier // represents the variables in order
+}
+
+// generates a new Destructuring instance
+func NewDestructuring(token *l.Token, variables ...*Identifier) *Destructuring {
+	return &Destructuring{BaseNode: BaseNode{token}, Variables: variables}
+}
+
+func (d *Destructuring) expressNode() {}
+
+func (d *Destructuring) Str() string {
+	names := make([]string, 0, len(d.Variables))
+	for _, variable := range d.Variables {
+		names = append(names, variable.Str())
+	}
+
+	return strings.Join(names, ", ")
+}
+
 // represents a key value expression:
 //		key => value
 type KeyValue struct {
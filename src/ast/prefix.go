@@ -3,6 +3,7 @@ package ast
 import (
 	l "aura/src/lexer"
 	"fmt"
+	"math/big"
 	"strings"
 )
 
@@ -62,6 +63,23 @@ func (i Integer) Str() string {
 	return fmt.Sprintf("%d", *i.Value)
 }
 
+// Represents an integer literal that does not fit in an int
+type BigInteger struct {
+	BaseNode          // Extends base node struct
+	Value    *big.Int // represents the value of the integer
+}
+
+// generates a new BigInteger instance
+func NewBigInteger(token *l.Token, value *big.Int) *BigInteger {
+	return &BigInteger{BaseNode: BaseNode{token}, Value: value}
+}
+
+func (b *BigInteger) expressNode() {}
+
+func (b *BigInteger) Str() string {
+	return b.Value.String()
+}
+
 // Represents a float expression
 type FloatExp struct {
 	BaseNode         // Extends base node struct
@@ -111,6 +129,34 @@ func (s StringLiteral) Str() string {
 	return s.Value
 }
 
+// represents an interpolated string like f"hola {nombre}", the parts are
+// string literals and the expressions written between braces
+type InterpolatedString struct {
+	BaseNode              // Extends base node struct
+	Parts    []Expression // represents the text and the expressions in order
+}
+
+// return a new interpolated string instance
+func NewInterpolatedString(token *l.Token, parts []Expression) *InterpolatedString {
+	return &InterpolatedString{BaseNode: BaseNode{token}, Parts: parts}
+}
+
+func (i InterpolatedString) expressNode() {}
+
+func (i InterpolatedString) Str() string {
+	var buf strings.Builder
+	for _, part := range i.Parts {
+		if str, isStr := part.(*StringLiteral); isStr {
+			escaped := strings.ReplaceAll(str.Value, "{", "{{")
+			buf.WriteString(strings.ReplaceAll(escaped, "}", "}}"))
+		} else {
+			buf.WriteString("{" + part.Str() + "}")
+		}
+	}
+
+	return fmt.Sprintf(`f"%s"`, buf.String())
+}
+
 // represents a null expression
 type NullExpression struct {
 	BaseNode // Extends base node struct
@@ -186,6 +232,7 @@ type ClassMethodExp struct {
 	Name     *Identifier   // represents the method identifier
 	Params   []*Identifier // represents the method params
 	Body     *Block        // represents the method body
+	Doc      string        // represents the doc comment of the method
 }
 
 // generates new class method expresion
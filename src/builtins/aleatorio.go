@@ -0,0 +1,181 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// represents the random source of an aleatorio module. every import of the
+// module has its own source so seeding one does not affect the others, the
+// mutex guards the source because a rand.Rand is not safe for concurrent use
+type randomSource struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// generates the aleatorio module
+func newRandomModule() *obj.Module {
+	source := &randomSource{rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+	return obj.NewModule("aleatorio", map[string]obj.Object{
+		"semilla":  source.locked(source.seed),
+		"entero":   source.locked(source.integer),
+		"flotante": source.locked(source.float),
+		"elegir":   source.locked(source.choice),
+		"mezclar":  source.locked(source.shuffle),
+		"muestra":  source.locked(source.sample),
+	})
+}
+
+// generates a builtin that holds the lock of the source while it runs
+func (r *randomSource) locked(fn obj.BuiltinFunction) *obj.Builtin {
+	return obj.NewBuiltin(func(args ...obj.Object) obj.Object {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return fn(args...)
+	})
+}
+
+// restart the source with the given seed so the next values are deterministic
+func (r *randomSource) seed(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("semilla", len(args), 1)
+	}
+
+	seed, err := intArgument("semilla", args[0])
+	if err != nil {
+		return err
+	}
+
+	r.rng = rand.New(rand.NewSource(int64(seed)))
+	return obj.SingletonNUll
+}
+
+// return a random integer between a and b including both
+func (r *randomSource) integer(args ...obj.Object) obj.Object {
+	bigStart, bigEnd, err := integerPair("entero", args)
+	if err != nil {
+		return err
+	}
+
+	if bigStart.Cmp(bigEnd) > 0 {
+		return &obj.Error{
+			Message: fmt.Sprintf("el inicio %s no puede ser mayor al final %s", bigStart, bigEnd),
+		}
+	}
+
+	if !bigStart.IsInt64() || !bigEnd.IsInt64() {
+		// the range of big integers does not fit in the span of an uint64
+		span := new(big.Int).Sub(bigEnd, bigStart)
+		offset := new(big.Int).Rand(r.rng, span.Add(span, big.NewInt(1)))
+		return obj.NewInteger(offset.Add(offset, bigStart))
+	}
+
+	start, end := bigStart.Int64(), bigEnd.Int64()
+
+	// the difference is computed without sign so it does not overflow
+	span := uint64(end) - uint64(start)
+	var offset uint64
+	switch {
+	case span < math.MaxInt64:
+		offset = uint64(r.rng.Int63n(int64(span) + 1))
+
+	case span == math.MaxUint64:
+		offset = r.rng.Uint64()
+
+	default:
+		// at least half of the values are in the range, so few retries are needed
+		for offset = r.rng.Uint64(); offset > span; offset = r.rng.Uint64() {
+		}
+	}
+
+	return &obj.Number{Value: int(uint64(start) + offset)}
+}
+
+// return a random float between 0 and 1
+func (r *randomSource) float(args ...obj.Object) obj.Object {
+	if len(args) != 0 {
+		return wrongNumberofArgs("flotante", len(args), 0)
+	}
+
+	return obj.NewFloat(r.rng.Float64())
+}
+
+// return a random element of the list
+func (r *randomSource) choice(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("elegir", len(args), 1)
+	}
+
+	list, isList := args[0].(*obj.List)
+	if !isList {
+		return unsoportedArgumentType("elegir", obj.Types[args[0].Type()])
+	}
+
+	if len(list.Values) == 0 {
+		return &obj.Error{Message: "no se puede elegir un elemento de una lista vacia"}
+	}
+
+	return list.Values[r.rng.Intn(len(list.Values))]
+}
+
+// shuffle the elements of the list in place
+func (r *randomSource) shuffle(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("mezclar", len(args), 1)
+	}
+
+	list, isList := args[0].(*obj.List)
+	if !isList {
+		return unsoportedArgumentType("mezclar", obj.Types[args[0].Type()])
+	}
+
+	if list.Frozen {
+		return &obj.Error{Message: fmt.Sprintf("El objecto %s no puede ser modificado", obj.Types[list.Type()])}
+	}
+
+	r.rng.Shuffle(len(list.Values), func(i, j int) {
+		list.Values[i], list.Values[j] = list.Values[j], list.Values[i]
+	})
+
+	return obj.SingletonNUll
+}
+
+// return a new list with k unique elements chosen from the list
+func (r *randomSource) sample(args ...obj.Object) obj.Object {
+	if len(args) != 2 {
+		return wrongNumberofArgs("muestra", len(args), 2)
+	}
+
+	list, isList := args[0].(*obj.List)
+	if !isList {
+		return unsoportedArgumentType("muestra", obj.Types[args[0].Type()])
+	}
+
+	size, err := intArgument("muestra", args[1])
+	if err != nil {
+		return err
+	}
+
+	if size < 0 || size > len(list.Values) {
+		return &obj.Error{
+			Message: fmt.Sprintf(
+				"la muestra debe estar entre 0 y %d, se recibio %d",
+				len(list.Values),
+				size,
+			),
+		}
+	}
+
+	sample := &obj.List{Values: make([]obj.Object, 0, size)}
+	for _, idx := range r.rng.Perm(len(list.Values))[:size] {
+		sample.Values = append(sample.Values, list.Values[idx])
+	}
+
+	return sample
+}
@@ -0,0 +1,318 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// represents the directory where the archivos module is allowed to read
+// and write. if is empty the module can access all the file system
+var sandboxRoot string
+
+// set the directory where the archivos module is allowed to read and write,
+// an empty root removes the restriction
+func SetSandboxRoot(root string) error {
+	if root == "" {
+		sandboxRoot = ""
+		return nil
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("la ruta %s no es valida", root)
+	}
+
+	fileInfo, err := os.Stat(absRoot)
+	if err != nil || !fileInfo.IsDir() {
+		return fmt.Errorf("la ruta %s no es un directorio", root)
+	}
+
+	// the paths are compared after resolving their links, so the root is too
+	absRoot, err = filepath.EvalSymlinks(absRoot)
+	if err != nil {
+		return fmt.Errorf("la ruta %s no es valida", root)
+	}
+
+	sandboxRoot = absRoot
+	return nil
+}
+
+// generates the archivos module
+func newFilesModule() *obj.Module {
+	return obj.NewModule("archivos", map[string]obj.Object{
+		"leer":              obj.NewBuiltin(readFile),
+		"escribir":          obj.NewBuiltin(writeFile),
+		"agregar":           obj.NewBuiltin(appendFile),
+		"lineas":            obj.NewBuiltin(fileLines),
+		"existe":            obj.NewBuiltin(pathExists),
+		"listar_directorio": obj.NewBuiltin(listDir),
+		"crear_directorio":  obj.NewBuiltin(makeDir),
+		"eliminar":          obj.NewBuiltin(removePath),
+	})
+}
+
+// return the content of the file as a string
+func readFile(args ...obj.Object) obj.Object {
+	path, err := pathArg("leer", args, 1)
+	if err != nil {
+		return err
+	}
+
+	content, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return fileError(readErr, path, "leer el archivo")
+	}
+
+	return &obj.String{Value: string(content)}
+}
+
+// write the text in the file, the file is created if not exists
+// and is replaced if exists
+func writeFile(args ...obj.Object) obj.Object {
+	return saveFile("escribir", args, os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+}
+
+// add the text at the end of the file, the file is created if not exists
+func appendFile(args ...obj.Object) obj.Object {
+	return saveFile("agregar", args, os.O_CREATE|os.O_WRONLY|os.O_APPEND)
+}
+
+// return an iterator that read the lines of the file one by one
+func fileLines(args ...obj.Object) obj.Object {
+	path, err := pathArg("lineas", args, 1)
+	if err != nil {
+		return err
+	}
+
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		return fileError(openErr, path, "leer el archivo")
+	}
+
+	scanner := bufio.NewScanner(file)
+	closed := false
+	release := func() {
+		if !closed {
+			closed = true
+			file.Close()
+		}
+	}
+
+	generator := obj.NewGenerator(path, func() (obj.Object, bool) {
+		if closed {
+			return nil, false
+		}
+
+		if scanner.Scan() {
+			return &obj.String{Value: scanner.Text()}, true
+		}
+
+		release()
+		if scanErr := scanner.Err(); scanErr != nil {
+			// the error is the last value, the loop stops and returns it
+			return fileError(scanErr, path, "leer el archivo"), true
+		}
+
+		return nil, false
+	})
+
+	// the loops close the file when they end before reading all the lines
+	generator.Release = release
+	return generator
+}
+
+// check if the path exists
+func pathExists(args ...obj.Object) obj.Object {
+	path, err := pathArg("existe", args, 1)
+	if err != nil {
+		return err
+	}
+
+	if _, statErr := os.Stat(path); statErr != nil {
+		return obj.SingletonFALSE
+	}
+
+	return obj.SingletonTRUE
+}
+
+// return a list with the names of the entries in the directory
+func listDir(args ...obj.Object) obj.Object {
+	path, err := pathArg("listar_directorio", args, 1)
+	if err != nil {
+		return err
+	}
+
+	entries, readErr := os.ReadDir(path)
+	if readErr != nil {
+		return fileError(readErr, path, "leer el directorio")
+	}
+
+	list := &obj.List{Values: make([]obj.Object, 0, len(entries))}
+	for _, entry := range entries {
+		list.Values = append(list.Values, &obj.String{Value: entry.Name()})
+	}
+
+	return list
+}
+
+// create the directory and all the parent directories that not exists
+func makeDir(args ...obj.Object) obj.Object {
+	path, err := pathArg("crear_directorio", args, 1)
+	if err != nil {
+		return err
+	}
+
+	if mkdirErr := os.MkdirAll(path, 0755); mkdirErr != nil {
+		return fileError(mkdirErr, path, "crear el directorio")
+	}
+
+	return obj.SingletonNUll
+}
+
+// remove the file or the empty directory
+func removePath(args ...obj.Object) obj.Object {
+	path, err := pathArg("eliminar", args, 1)
+	if err != nil {
+		return err
+	}
+
+	if removeErr := os.Remove(path); removeErr != nil {
+		return fileError(removeErr, path, "eliminar")
+	}
+
+	return obj.SingletonNUll
+}
+
+// write the text argument in the file opened with the given flags
+func saveFile(funcName string, args []obj.Object, flags int) obj.Object {
+	path, err := pathArg(funcName, args, 2)
+	if err != nil {
+		return err
+	}
+
+	text, isStr := args[1].(*obj.String)
+	if !isStr {
+		return unsoportedArgumentType(funcName, obj.Types[args[1].Type()])
+	}
+
+	file, openErr := os.OpenFile(path, flags, 0644)
+	if openErr != nil {
+		return fileError(openErr, path, "escribir el archivo")
+	}
+	defer file.Close()
+
+	if _, writeErr := file.WriteString(text.Value); writeErr != nil {
+		return fileError(writeErr, path, "escribir el archivo")
+	}
+
+	return obj.SingletonNUll
+}
+
+// check the number of args and return the path in the first argument
+// resolved against the sandbox root
+func pathArg(funcName string, args []obj.Object, expected int) (string, *obj.Error) {
+	if len(args) != expected {
+		return "", wrongNumberofArgs(funcName, len(args), expected)
+	}
+
+	path, isStr := args[0].(*obj.String)
+	if !isStr {
+		return "", unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+	}
+
+	return resolvePath(path.Value)
+}
+
+// resolve the path inside the sandbox root if there is one and check that
+// the path does not go outside of it
+func resolvePath(path string) (string, *obj.Error) {
+	if sandboxRoot == "" {
+		return path, nil
+	}
+
+	resolved := path
+	if !filepath.IsAbs(path) {
+		resolved = filepath.Join(sandboxRoot, path)
+	}
+
+	// the links are resolved so a link inside the root can not point outside
+	resolved, err := evalSymlinks(filepath.Clean(resolved))
+	if err != nil {
+		return "", &obj.Error{Message: fmt.Sprintf("no se pudo resolver la ruta %s", path)}
+	}
+
+	rel, err := filepath.Rel(sandboxRoot, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &obj.Error{
+			Message: fmt.Sprintf("la ruta %s esta fuera del directorio permitido", path),
+		}
+	}
+
+	return resolved, nil
+}
+
+// resolve the links in the path, the last parts of the path that do not
+// exist yet are kept as they are. the links to paths that do not exist are
+// followed too, because writing in them creates the file they point to
+func evalSymlinks(path string) (string, error) {
+	missing := ""
+	current := path
+
+	for links := 0; links < maxSymlinks; {
+		resolved, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			return filepath.Join(resolved, missing), nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		if info, statErr := os.Lstat(current); statErr == nil && info.Mode()&os.ModeSymlink != 0 {
+			target, linkErr := os.Readlink(current)
+			if linkErr != nil {
+				return "", linkErr
+			}
+
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(current), target)
+			}
+
+			current = target
+			links++
+			continue
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return filepath.Join(current, missing), nil
+		}
+
+		missing = filepath.Join(filepath.Base(current), missing)
+		current = parent
+	}
+
+	return "", errors.New("demasiados enlaces")
+}
+
+// the links followed before giving up, like the limit of the os
+const maxSymlinks = 255
+
+// translate the go file errors to an error object
+func fileError(err error, path string, action string) *obj.Error {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return &obj.Error{Message: fmt.Sprintf("la ruta %s no existe", path)}
+
+	case errors.Is(err, os.ErrPermission):
+		return &obj.Error{Message: fmt.Sprintf("no tienes permisos para %s %s", action, path)}
+
+	default:
+		return &obj.Error{Message: fmt.Sprintf("no se pudo %s %s", action, path)}
+	}
+}
@@ -1,10 +1,13 @@
 package builtins
 
 import (
+	"aura/src/ast"
 	obj "aura/src/object"
 	"bufio"
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"os"
 	"strconv"
 	"strings"
@@ -25,6 +28,13 @@ func wrongNumberofArgs(funcName string, found, actual int) *obj.Error {
 	}
 }
 
+// return an error indicating the builtin accepts between min and max args
+func wrongNumberofArgsRange(funcName string, found, min, max int) *obj.Error {
+	return &obj.Error{
+		Message: fmt.Sprintf("numero incorrecto de argumentos para %s, se recibieron %d, se requieren entre %d y %d", funcName, found, min, max),
+	}
+}
+
 func unsoportedArgumentType(funcname, objType string) *obj.Error {
 	return &obj.Error{
 		Message: fmt.Sprintf("argumento para %s no valido, se recibio %s", funcname, objType),
@@ -48,6 +58,12 @@ func Longitud(args ...obj.Object) obj.Object {
 	case *obj.Map:
 		return &obj.Number{Value: len(arg.Store)}
 
+	case *obj.Set:
+		return &obj.Number{Value: arg.Len()}
+
+	case *obj.Tuple:
+		return &obj.Number{Value: len(arg.Values)}
+
 	default:
 		return unsoportedArgumentType("largo", obj.Types[args[0].Type()])
 	}
@@ -95,11 +111,20 @@ func castInt(args ...obj.Object) obj.Object {
 	case *obj.Number:
 		return node
 
+	case *obj.BigInteger:
+		return node
+
 	case *obj.String:
 		return toInt(node.Value)
 
+	case *obj.Decimal:
+		return truncateRat(node.Value)
+
+	case *obj.Fraction:
+		return truncateRat(node.Value)
+
 	case *obj.Float:
-		return &obj.Number{Value: int(node.Value)}
+		return floatToInteger("entero", math.Trunc(node.Value))
 
 	default:
 		return unsoportedArgumentType("entero", obj.Types[args[0].Type()])
@@ -125,6 +150,18 @@ func castFloat(args ...obj.Object) obj.Object {
 	case *obj.Number:
 		return &obj.Float{Value: float64(node.Value)}
 
+	case *obj.BigInteger:
+		value, _ := new(big.Float).SetInt(node.Value).Float64()
+		return &obj.Float{Value: value}
+
+	case *obj.Decimal:
+		value, _ := node.Value.Float64()
+		return &obj.Float{Value: value}
+
+	case *obj.Fraction:
+		value, _ := node.Value.Float64()
+		return &obj.Float{Value: value}
+
 	case *obj.String:
 		val, err := strconv.ParseFloat(node.Value, 32)
 		if err != nil {
@@ -138,9 +175,89 @@ func castFloat(args ...obj.Object) obj.Object {
 	}
 }
 
+// convert an integer, a float or a string to an exact decimal
+func castDecimal(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("decimal", len(args), 1)
+	}
+
+	switch node := args[0].(type) {
+	case *obj.Decimal:
+		return node
+
+	case *obj.Float:
+		// the shortest representation of the float is used, so 0.1 is 0.1
+		decimal, _ := obj.ParseDecimal(strconv.FormatFloat(node.Value, 'f', -1, 64))
+		return decimal
+
+	case *obj.String:
+		decimal, isDecimal := obj.ParseDecimal(strings.TrimSpace(node.Value))
+		if !isDecimal {
+			return &obj.Error{Message: fmt.Sprintf("no se pudo parsear como decimal %s", node.Value)}
+		}
+
+		return decimal
+
+	default:
+		value, isInt := obj.ToBigInt(args[0])
+		if !isInt {
+			return unsoportedArgumentType("decimal", obj.Types[args[0].Type()])
+		}
+
+		return obj.NewDecimal(new(big.Rat).SetInt(value), 0)
+	}
+}
+
+// generates a fraction from a numerator and a denominator, from a string
+// like 1/3 or from any other number
+func castFraction(args ...obj.Object) obj.Object {
+	if len(args) == 2 {
+		numerator, isNumInt := obj.ToBigInt(args[0])
+		denominator, isDenInt := obj.ToBigInt(args[1])
+		if !isNumInt || !isDenInt {
+			return &obj.Error{Message: "el numerador y el denominador de una fraccion deben ser enteros"}
+		}
+
+		if denominator.Sign() == 0 {
+			return &obj.Error{Message: "el denominador de una fraccion no puede ser 0"}
+		}
+
+		return obj.NewFraction(new(big.Rat).SetFrac(numerator, denominator))
+	}
+
+	if len(args) != 1 {
+		return wrongNumberofArgs("fraccion", len(args), 2)
+	}
+
+	switch node := args[0].(type) {
+	case *obj.Float:
+		if math.IsInf(node.Value, 0) || math.IsNaN(node.Value) {
+			return &obj.Error{Message: fmt.Sprintf("no se puede convertir %s a fraccion", node.Inspect())}
+		}
+
+		return obj.NewFraction(new(big.Rat).SetFloat64(node.Value))
+
+	case *obj.String:
+		value, isFraction := new(big.Rat).SetString(strings.TrimSpace(node.Value))
+		if !isFraction {
+			return &obj.Error{Message: fmt.Sprintf("no se pudo parsear como fraccion %s", node.Value)}
+		}
+
+		return obj.NewFraction(value)
+
+	default:
+		value, isExact := obj.ToRat(args[0])
+		if !isExact {
+			return unsoportedArgumentType("fraccion", obj.Types[args[0].Type()])
+		}
+
+		return obj.NewFraction(new(big.Rat).Set(value))
+	}
+}
+
 func formatrArgs(args ...obj.Object) obj.Object {
-	if len(args) <= 1 {
-		return wrongNumberofArgs("format", 0, 100)
+	if len(args) == 0 {
+		return wrongNumberofArgs("formatear", 0, 1)
 	}
 
 	str, isStr := args[0].(*obj.String)
@@ -150,13 +267,17 @@ func formatrArgs(args ...obj.Object) obj.Object {
 		}
 	}
 
-	formated := formatString(str.Value, args[1:])
+	formated, err := FormatString(str.Value, args[1:])
+	if err != nil {
+		return err
+	}
+
 	return &obj.String{Value: formated}
 }
 
 func printF(args ...obj.Object) obj.Object {
-	if len(args) <= 1 {
-		return wrongNumberofArgs("format", 0, 100)
+	if len(args) == 0 {
+		return wrongNumberofArgs("escribirF", 0, 1)
 	}
 
 	str, isStr := args[0].(*obj.String)
@@ -166,7 +287,11 @@ func printF(args ...obj.Object) obj.Object {
 		}
 	}
 
-	formated := formatString(str.Value, args[1:])
+	formated, err := FormatString(str.Value, args[1:])
+	if err != nil {
+		return err
+	}
+
 	defer writer.Flush()
 	writer.WriteString(formated + "\n")
 	return obj.SingletonNUll
@@ -174,6 +299,13 @@ func printF(args ...obj.Object) obj.Object {
 
 // same as python range function
 func rango(args ...obj.Object) obj.Object {
+	for _, arg := range args {
+		if _, isBig := arg.(*obj.BigInteger); isBig {
+			_, err := intArgument("rango", arg)
+			return err
+		}
+	}
+
 	switch len(args) {
 	case 1:
 		return makeOneArgList(args[0])
@@ -203,6 +335,10 @@ func slep(args ...obj.Object) obj.Object {
 		time.Sleep(time.Duration(arg.Value * float64(time.Second)))
 		return obj.SingletonNUll
 
+	case *obj.Duration:
+		time.Sleep(arg.Value)
+		return obj.SingletonNUll
+
 	default:
 		return unsoportedArgumentType("dormir", obj.Types[arg.Type()])
 	}
@@ -217,27 +353,15 @@ func Tipo(args ...obj.Object) obj.Object {
 	return &obj.String{Value: obj.Types[args[0].Type()]}
 }
 
+// return the sum of the numbers in a list. the result is a float
+// if any of the numbers is a float
 func sum(args ...obj.Object) obj.Object {
 	if len(args) > 1 || len(args) < 1 {
-		return wrongNumberofArgs("sum", len(args), 1)
+		return wrongNumberofArgs("suma", len(args), 1)
 	}
 
 	if list, isList := args[0].(*obj.List); isList {
-		var res obj.Number
-		for _, value := range list.Values {
-			switch item := value.(type) {
-			case *obj.Number:
-				res.Value += item.Value
-
-			case *obj.Float:
-				res.Value += int(item.Value)
-
-			default:
-				return unsoportedArgumentType("suma", obj.Types[value.Type()])
-			}
-		}
-
-		return &res
+		return sumValues("suma", list.Values)
 	}
 
 	return unsoportedArgumentType("suma", obj.Types[args[0].Type()])
@@ -251,7 +375,25 @@ func abs(args ...obj.Object) obj.Object {
 
 	switch node := args[0].(type) {
 	case *obj.Number:
-		return &obj.Number{Value: int(math.Abs(float64(node.Value)))}
+		if node.Value == math.MinInt {
+			// the absolute value of the min int does not fit in an int
+			return obj.NewInteger(new(big.Int).Neg(big.NewInt(math.MinInt64)))
+		}
+
+		if node.Value < 0 {
+			return &obj.Number{Value: -node.Value}
+		}
+
+		return &obj.Number{Value: node.Value}
+
+	case *obj.BigInteger:
+		return obj.NewInteger(new(big.Int).Abs(node.Value))
+
+	case *obj.Decimal:
+		return obj.NewDecimal(new(big.Rat).Abs(node.Value), node.Scale)
+
+	case *obj.Fraction:
+		return obj.NewFraction(new(big.Rat).Abs(node.Value))
 
 	case *obj.Float:
 		return &obj.Float{Value: math.Abs(node.Value)}
@@ -261,6 +403,69 @@ func abs(args ...obj.Object) obj.Object {
 	}
 }
 
+// return an immutable tuple with the given values
+func tuple(args ...obj.Object) obj.Object {
+	return obj.NewTuple(args...)
+}
+
+// freeze the list, map or set and all the values inside it so they can not
+// be modified, return the same value
+func freeze(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("congelar", len(args), 1)
+	}
+
+	obj.Freeze(args[0])
+	return args[0]
+}
+
+// return the doc comment of a function or a class, the doc comment of a
+// class is followed by the doc comments of its methods. the builtins do not
+// have doc comments
+func help(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("ayuda", len(args), 1)
+	}
+
+	switch node := args[0].(type) {
+	case *obj.Def:
+		return &obj.String{Value: docOrDefault(node.Doc)}
+
+	case *obj.Builtin:
+		return &obj.String{Value: "funcion integrada"}
+
+	case *obj.Class:
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "clase %s(%s)\n%s", node.Name.Value, joinIdentifiers(node.Params), docOrDefault(node.Doc))
+		for _, method := range node.Methods {
+			fmt.Fprintf(&buf, "\n\n%s(%s)\n%s", method.Name.Value, joinIdentifiers(method.Params), docOrDefault(method.Doc))
+		}
+
+		return &obj.String{Value: buf.String()}
+
+	default:
+		return unsoportedArgumentType("ayuda", obj.Types[args[0].Type()])
+	}
+}
+
+func docOrDefault(doc string) string {
+	if doc == "" {
+		return "sin documentacion"
+	}
+
+	return doc
+}
+
+// join the names of the identifiers with commas
+func joinIdentifiers(identifiers []*ast.Identifier) string {
+	names := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		names = append(names, identifier.Value)
+	}
+
+	return strings.Join(names, ", ")
+}
+
 // input function to recibe input from console
 func input(scan *bufio.Scanner) string {
 	scan.Scan()
@@ -268,9 +473,19 @@ func input(scan *bufio.Scanner) string {
 	return str
 }
 
+// return the integer part of the value
+func truncateRat(value *big.Rat) obj.Object {
+	return obj.NewInteger(new(big.Int).Quo(value.Num(), value.Denom()))
+}
+
 // perform the string to int conversion and handle the posibe errror
 func toInt(str string) obj.Object {
 	number, err := strconv.Atoi(str)
+	if errors.Is(err, strconv.ErrRange) {
+		value, _ := new(big.Int).SetString(str, 10)
+		return obj.NewInteger(value)
+	}
+
 	if err != nil {
 		return &obj.Error{Message: fmt.Sprintf("No se puede parsear como entero %s", str)}
 	}
@@ -279,31 +494,78 @@ func toInt(str string) obj.Object {
 }
 
 var BUILTINS = map[string]*obj.Builtin{
-	"largo":        obj.NewBuiltin(Longitud),
-	"escribir":     obj.NewBuiltin(Escribir),
-	"recibir":      obj.NewBuiltin(Recibir),
-	"tipo":         obj.NewBuiltin(Tipo),
-	"entero":       obj.NewBuiltin(castInt),
-	"texto":        obj.NewBuiltin(castString),
-	"rango":        obj.NewBuiltin(rango),
-	"agregar":      obj.NewBuiltin(add),
-	"pop":          obj.NewBuiltin(pop),
-	"popIndice":    obj.NewBuiltin(remove),
-	"contiene":     obj.NewBuiltin(contains),
-	"valores":      obj.NewBuiltin(values),
-	"mayusculas":   obj.NewBuiltin(toUppper),
-	"minusculas":   obj.NewBuiltin(toLower),
-	"dormir":       obj.NewBuiltin(slep),
-	"es_mayuscula": obj.NewBuiltin(isUpper),
-	"es_minuscula": obj.NewBuiltin(isLower),
-	"formatear":    obj.NewBuiltin(formatrArgs),
-	"escribirF":    obj.NewBuiltin(printF),
-	"map":          obj.NewBuiltin(mapList),
-	"porCada":      obj.NewBuiltin(forEach),
-	"filtrar":      obj.NewBuiltin(filter),
-	"contar":       obj.NewBuiltin(count),
-	"separar":      obj.NewBuiltin(split),
-	"abs":          obj.NewBuiltin(abs),
-	"flotante":     obj.NewBuiltin(castFloat),
-	"suma":         obj.NewBuiltin(sum),
+	"largo":            obj.NewBuiltin(Longitud),
+	"escribir":         obj.NewBuiltin(Escribir),
+	"recibir":          obj.NewBuiltin(Recibir),
+	"tipo":             obj.NewBuiltin(Tipo),
+	"entero":           obj.NewBuiltin(castInt),
+	"texto":            obj.NewBuiltin(castString),
+	"rango":            obj.NewBuiltin(rango),
+	"agregar":          obj.NewBuiltin(add),
+	"pop":              obj.NewBuiltin(pop),
+	"popIndice":        obj.NewBuiltin(remove),
+	"contiene":         obj.NewBuiltin(contains),
+	"valores":          obj.NewBuiltin(values),
+	"mayusculas":       obj.NewBuiltin(toUppper),
+	"minusculas":       obj.NewBuiltin(toLower),
+	"dormir":           obj.NewBuiltin(slep),
+	"es_mayuscula":     obj.NewBuiltin(isUpper),
+	"es_minuscula":     obj.NewBuiltin(isLower),
+	"formatear":        obj.NewBuiltin(formatrArgs),
+	"escribirF":        obj.NewBuiltin(printF),
+	"map":              obj.NewBuiltin(mapList),
+	"porCada":          obj.NewBuiltin(forEach),
+	"filtrar":          obj.NewBuiltin(filter),
+	"contar":           obj.NewBuiltin(count),
+	"separar":          obj.NewBuiltin(split),
+	"abs":              obj.NewBuiltin(abs),
+	"flotante":         obj.NewBuiltin(castFloat),
+	"suma":             obj.NewBuiltin(sum),
+	"recortar":         obj.NewBuiltin(trim),
+	"reemplazar":       obj.NewBuiltin(replace),
+	"empieza_con":      obj.NewBuiltin(methodWithString("empieza_con", obj.STARTSWITH)),
+	"termina_con":      obj.NewBuiltin(methodWithString("termina_con", obj.ENDSWITH)),
+	"buscar":           obj.NewBuiltin(methodWithString("buscar", obj.FIND)),
+	"repetir":          obj.NewBuiltin(repeat),
+	"unir":             obj.NewBuiltin(join),
+	"rellenar_izq":     obj.NewBuiltin(padMethod("rellenar_izq", obj.PADLEFT)),
+	"rellenar_der":     obj.NewBuiltin(padMethod("rellenar_der", obj.PADRIGHT)),
+	"invertir":         obj.NewBuiltin(methodWithoutArgs("invertir", obj.REVERSE)),
+	"es_numero":        obj.NewBuiltin(methodWithoutArgs("es_numero", obj.ISNUMBER)),
+	"es_letra":         obj.NewBuiltin(methodWithoutArgs("es_letra", obj.ISLETTER)),
+	"caracteres":       obj.NewBuiltin(methodWithoutArgs("caracteres", obj.CHARS)),
+	"formato":          obj.NewBuiltin(format),
+	"rebanar":          obj.NewBuiltin(slice),
+	"ordenar":          obj.NewBuiltin(sortList),
+	"insertar":         obj.NewBuiltin(insert),
+	"extender":         obj.NewBuiltin(extend),
+	"indice":           obj.NewBuiltin(methodWithValue("indice", obj.INDEX)),
+	"unicos":           obj.NewBuiltin(methodWithoutArgs("unicos", obj.UNIQUE)),
+	"reducir":          obj.NewBuiltin(reduce),
+	"zip":              obj.NewBuiltin(zip),
+	"enumerar":         obj.NewBuiltin(methodWithOptionalInt("enumerar", obj.ENUMERATE)),
+	"algun":            obj.NewBuiltin(methodWithFunction("algun", obj.ANY, 1)),
+	"todos":            obj.NewBuiltin(methodWithFunction("todos", obj.ALL, 1)),
+	"min":              obj.NewBuiltin(methodWithOptionalFunction("min", obj.MIN)),
+	"max":              obj.NewBuiltin(methodWithOptionalFunction("max", obj.MAX)),
+	"copiar":           obj.NewBuiltin(methodWithoutArgs("copiar", obj.COPY)),
+	"aplanar":          obj.NewBuiltin(methodWithOptionalInt("aplanar", obj.FLATTEN)),
+	"agrupar_por":      obj.NewBuiltin(methodWithFunction("agrupar_por", obj.GROUPBY, 1)),
+	"llaves":           obj.NewBuiltin(methodWithoutArgs("llaves", obj.KEYS)),
+	"pares":            obj.NewBuiltin(methodWithoutArgs("pares", obj.PAIRS)),
+	"items":            obj.NewBuiltin(methodWithoutArgs("items", obj.PAIRS)),
+	"eliminar":         obj.NewBuiltin(methodWithValue("eliminar", obj.DELETE)),
+	"obtener":          obj.NewBuiltin(get),
+	"fusionar":         obj.NewBuiltin(methodWithMap("fusionar", obj.MERGE)),
+	"actualizar":       obj.NewBuiltin(methodWithMap("actualizar", obj.UPDATE)),
+	"tiene":            obj.NewBuiltin(methodWithValue("tiene", obj.HAS)),
+	"limpiar":          obj.NewBuiltin(methodWithoutArgs("limpiar", obj.CLEAR)),
+	"transformar":      obj.NewBuiltin(methodWithFunction("transformar", obj.TRANSFORM, 2)),
+	"es_subconjunto":   obj.NewBuiltin(methodWithSet("es_subconjunto", obj.SUBSET)),
+	"es_superconjunto": obj.NewBuiltin(methodWithSet("es_superconjunto", obj.SUPERSET)),
+	"tupla":            obj.NewBuiltin(tuple),
+	"congelar":         obj.NewBuiltin(freeze),
+	"decimal":          obj.NewBuiltin(castDecimal),
+	"fraccion":         obj.NewBuiltin(castFraction),
+	"ayuda":            obj.NewBuiltin(help),
 }
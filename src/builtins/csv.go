@@ -0,0 +1,407 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// represents the options that can be given to the csv functions in a map like:
+//		mapa{"delimitador" => ";", "encabezados" => verdadero}
+type csvOptions struct {
+	delimiter  rune // represents the character that separate the fields
+	headers    bool // represents if the first row are the names of the fields
+	lazyQuotes bool // represents if the quotes are allowed inside not quoted fields
+}
+
+// generates the csv module
+func newCSVModule() *obj.Module {
+	return obj.NewModule("csv", map[string]obj.Object{
+		"leer":        obj.NewBuiltin(csvRead),
+		"decodificar": obj.NewBuiltin(csvDecode),
+		"filas":       obj.NewBuiltin(csvRows),
+		"escribir":    obj.NewBuiltin(csvWrite),
+		"codificar":   obj.NewBuiltin(csvEncode),
+	})
+}
+
+// read all the rows of a csv file
+func csvRead(args ...obj.Object) obj.Object {
+	path, options, err := csvPathArgs("leer", args)
+	if err != nil {
+		return err
+	}
+
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		return fileError(openErr, path, "leer el archivo")
+	}
+	defer file.Close()
+
+	return readAllRows(file, options)
+}
+
+// read all the rows of a csv text
+func csvDecode(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgsRange("decodificar", len(args), 1, 2)
+	}
+
+	text, isStr := args[0].(*obj.String)
+	if !isStr {
+		return unsoportedArgumentType("decodificar", obj.Types[args[0].Type()])
+	}
+
+	options, err := parseCSVOptions("decodificar", args[1:])
+	if err != nil {
+		return err
+	}
+
+	return readAllRows(strings.NewReader(text.Value), options)
+}
+
+// return an iterator that read the rows of a csv file one by one
+func csvRows(args ...obj.Object) obj.Object {
+	path, options, err := csvPathArgs("filas", args)
+	if err != nil {
+		return err
+	}
+
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		return fileError(openErr, path, "leer el archivo")
+	}
+
+	reader := newCSVReader(file, options)
+	var header []string
+	if options.headers {
+		record, readErr := reader.Read()
+		if readErr != nil && readErr != io.EOF {
+			file.Close()
+			return csvError(readErr)
+		}
+
+		header = record
+	}
+
+	closed := false
+	release := func() {
+		if !closed {
+			closed = true
+			file.Close()
+		}
+	}
+
+	generator := obj.NewGenerator(path, func() (obj.Object, bool) {
+		if closed {
+			return nil, false
+		}
+
+		record, readErr := reader.Read()
+		if readErr != nil {
+			release()
+			if readErr == io.EOF {
+				return nil, false
+			}
+
+			// the error is the last value, the loop stops and returns it like csv.leer
+			return csvError(readErr), true
+		}
+
+		row := recordToObject(record, header, options)
+		if _, isErr := row.(*obj.Error); isErr {
+			release()
+		}
+
+		return row, true
+	})
+
+	// the loops close the file when they end before reading all the rows
+	generator.Release = release
+	return generator
+}
+
+// write the rows in a csv file, the rows can be lists or maps
+func csvWrite(args ...obj.Object) obj.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return wrongNumberofArgsRange("escribir", len(args), 2, 3)
+	}
+
+	path, err := pathArg("escribir", args[:1], 1)
+	if err != nil {
+		return err
+	}
+
+	options, err := parseCSVOptions("escribir", args[2:])
+	if err != nil {
+		return err
+	}
+
+	// the rows are encoded before opening the file, so an invalid row does
+	// not leave the file empty or half written
+	var buf bytes.Buffer
+	if err := writeRows("escribir", &buf, args[1], options); err != nil {
+		return err
+	}
+
+	if writeErr := os.WriteFile(path, buf.Bytes(), 0644); writeErr != nil {
+		return fileError(writeErr, path, "escribir el archivo")
+	}
+
+	return obj.SingletonNUll
+}
+
+// return the rows as a csv text, the rows can be lists or maps
+func csvEncode(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgsRange("codificar", len(args), 1, 2)
+	}
+
+	options, err := parseCSVOptions("codificar", args[1:])
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	if err := writeRows("codificar", &buf, args[0], options); err != nil {
+		return err
+	}
+
+	return &obj.String{Value: buf.String()}
+}
+
+// check the arguments of the functions that recibe a path and the options
+func csvPathArgs(funcName string, args []obj.Object) (string, *csvOptions, *obj.Error) {
+	if len(args) != 1 && len(args) != 2 {
+		return "", nil, wrongNumberofArgsRange(funcName, len(args), 1, 2)
+	}
+
+	path, err := pathArg(funcName, args[:1], 1)
+	if err != nil {
+		return "", nil, err
+	}
+
+	options, err := parseCSVOptions(funcName, args[1:])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return path, options, nil
+}
+
+// read the options map if was given
+func parseCSVOptions(funcName string, args []obj.Object) (*csvOptions, *obj.Error) {
+	options := &csvOptions{delimiter: ','}
+	if len(args) == 0 {
+		return options, nil
+	}
+
+	hashMap, isMap := args[0].(*obj.Map)
+	if !isMap {
+		return nil, unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+	}
+
+	if delimiter := hashMap.Get(&obj.String{Value: "delimitador"}); delimiter != obj.NullVAlue {
+		str, isStr := delimiter.(*obj.String)
+		if !isStr || utf8.RuneCountInString(str.Value) != 1 {
+			return nil, &obj.Error{Message: "el delimitador debe ser un solo caracter"}
+		}
+
+		options.delimiter, _ = utf8.DecodeRuneInString(str.Value)
+	}
+
+	var err *obj.Error
+	if options.headers, err = boolOption(hashMap, "encabezados"); err != nil {
+		return nil, err
+	}
+
+	if options.lazyQuotes, err = boolOption(hashMap, "comillas_flexibles"); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// return the value of a boolean option, false if the option was not given
+func boolOption(hashMap *obj.Map, name string) (bool, *obj.Error) {
+	value := hashMap.Get(&obj.String{Value: name})
+	if value == obj.NullVAlue {
+		return false, nil
+	}
+
+	boolean, isBool := value.(*obj.Bool)
+	if !isBool {
+		return false, &obj.Error{Message: fmt.Sprintf("la opcion %s debe ser un booleano", name)}
+	}
+
+	return boolean.Value, nil
+}
+
+// generates a csv reader configured with the options
+func newCSVReader(source io.Reader, options *csvOptions) *csv.Reader {
+	reader := csv.NewReader(source)
+	reader.Comma = options.delimiter
+	reader.LazyQuotes = options.lazyQuotes
+	reader.FieldsPerRecord = -1
+	return reader
+}
+
+// read all the rows and return a list of lists or a list of maps if
+// the first row is the header
+func readAllRows(source io.Reader, options *csvOptions) obj.Object {
+	records, err := newCSVReader(source, options).ReadAll()
+	if err != nil {
+		return csvError(err)
+	}
+
+	var header []string
+	if options.headers && len(records) != 0 {
+		header, records = records[0], records[1:]
+	}
+
+	rows := &obj.List{Values: make([]obj.Object, 0, len(records))}
+	for _, record := range records {
+		row := recordToObject(record, header, options)
+		if err, isErr := row.(*obj.Error); isErr {
+			return err
+		}
+
+		rows.Values = append(rows.Values, row)
+	}
+
+	return rows
+}
+
+// convert a csv record to a list of strings or to a map keyed by the header
+func recordToObject(record []string, header []string, options *csvOptions) obj.Object {
+	if !options.headers {
+		row := &obj.List{Values: make([]obj.Object, 0, len(record))}
+		for _, field := range record {
+			row.Values = append(row.Values, &obj.String{Value: field})
+		}
+
+		return row
+	}
+
+	if len(record) != len(header) {
+		return &obj.Error{
+			Message: fmt.Sprintf(
+				"la fila tiene %d campos pero el encabezado tiene %d",
+				len(record),
+				len(header),
+			),
+		}
+	}
+
+	row := obj.NewMap()
+	for idx, field := range record {
+		row.UpdateKey(&obj.String{Value: header[idx]}, &obj.String{Value: field})
+	}
+
+	return row
+}
+
+// write the rows in the destination. if the rows are maps the header
+// is generated with the keys of the maps
+func writeRows(funcName string, destination io.Writer, rowsArg obj.Object, options *csvOptions) *obj.Error {
+	rows, isList := rowsArg.(*obj.List)
+	if !isList {
+		return unsoportedArgumentType(funcName, obj.Types[rowsArg.Type()])
+	}
+
+	writer := csv.NewWriter(destination)
+	writer.Comma = options.delimiter
+
+	header := csvHeader(rows)
+	if len(header) != 0 {
+		names := make([]string, 0, len(header))
+		for _, key := range header {
+			names = append(names, key.Inspect())
+		}
+
+		writer.Write(names)
+	}
+
+	for _, row := range rows.Values {
+		var record []string
+		switch node := row.(type) {
+		case *obj.List:
+			for _, value := range node.Values {
+				record = append(record, csvField(value))
+			}
+
+		case *obj.Map:
+			for _, key := range header {
+				record = append(record, csvField(node.Get(key)))
+			}
+
+		default:
+			return &obj.Error{
+				Message: fmt.Sprintf("las filas del csv deben ser listas o mapas, se recibio %s", obj.Types[row.Type()]),
+			}
+		}
+
+		if err := writer.Write(record); err != nil {
+			return &obj.Error{Message: "no se pudo escribir el csv"}
+		}
+	}
+
+	writer.Flush()
+	if writer.Error() != nil {
+		return &obj.Error{Message: "no se pudo escribir el csv"}
+	}
+
+	return nil
+}
+
+// return the keys of all the maps in the rows in the order they appear
+func csvHeader(rows *obj.List) []obj.Object {
+	var header []obj.Object
+	seen := map[string]bool{}
+	for _, row := range rows.Values {
+		hashMap, isMap := row.(*obj.Map)
+		if !isMap {
+			continue
+		}
+
+		for _, key := range hashMap.Keys {
+			if !seen[obj.HashKey(key)] {
+				seen[obj.HashKey(key)] = true
+				header = append(header, key)
+			}
+		}
+	}
+
+	return header
+}
+
+// return the text of a value in the csv, the null values are empty fields
+func csvField(value obj.Object) string {
+	if value == obj.NullVAlue {
+		return ""
+	}
+
+	return value.Inspect()
+}
+
+// translate the go csv errors to an error object
+func csvError(err error) *obj.Error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &obj.Error{
+			Message: fmt.Sprintf(
+				"el csv no es valido, error en la linea %d columna %d",
+				parseErr.Line,
+				parseErr.Column,
+			),
+		}
+	}
+
+	return &obj.Error{Message: "no se pudo leer el csv"}
+}
@@ -0,0 +1,442 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// represents a format specifier like {:>10.2f}
+type formatSpec struct {
+	fill      rune   // the character used to fill the width
+	align     rune   // one of < > ^ or 0 if the default alignment is used
+	sign      rune   // one of + - or a space
+	zero      bool   // fill the width with zeros after the sign
+	width     int    // the minimum width of the result
+	grouping  string // the thousands separator
+	precision int    // the number of decimals, -1 if not given
+	verb      rune   // the presentation type like f, d or x
+}
+
+// replace the placeholders in the string with the arguments. a placeholder
+// can be empty {}, a position {0} or a name {nombre} looked up in a map
+// passed as the last argument, followed by a specifier like {:.2f}
+func FormatString(str string, args []obj.Object) (string, *obj.Error) {
+	var result strings.Builder
+	used := make([]bool, len(args))
+	automatic, manual := false, false
+	next := 0
+
+	for i := 0; i < len(str); i++ {
+		char := str[i]
+		if char == '}' {
+			if i+1 < len(str) && str[i+1] == '}' {
+				result.WriteByte('}')
+				i++
+				continue
+			}
+
+			return "", &obj.Error{Message: "'}' sin pareja en el formato"}
+		}
+
+		if char != '{' {
+			result.WriteByte(char)
+			continue
+		}
+
+		if i+1 < len(str) && str[i+1] == '{' {
+			result.WriteByte('{')
+			i++
+			continue
+		}
+
+		end := strings.IndexByte(str[i:], '}')
+		if end == -1 {
+			return "", &obj.Error{Message: "'{' sin cerrar en el formato"}
+		}
+
+		field, specifier, _ := strings.Cut(str[i+1:i+end], ":")
+		i += end
+
+		var value obj.Object
+		var err *obj.Error
+		switch {
+		case field == "":
+			if manual {
+				return "", mixedFieldsError()
+			}
+
+			automatic = true
+			value, err = formatArgument(next, args, used)
+			next++
+
+		case isDigits(field):
+			if automatic {
+				return "", mixedFieldsError()
+			}
+
+			manual = true
+			index, _ := strconv.Atoi(field)
+			value, err = formatArgument(index, args, used)
+
+		default:
+			value, err = namedFormatArgument(field, args, used)
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		spec, err := parseFormatSpec(specifier)
+		if err != nil {
+			return "", err
+		}
+
+		formatted, err := spec.format(value)
+		if err != nil {
+			return "", err
+		}
+
+		result.WriteString(formatted)
+	}
+
+	for idx, isUsed := range used {
+		if !isUsed {
+			return "", &obj.Error{
+				Message: fmt.Sprintf("sobran argumentos para formatear, el argumento %d no se uso", idx),
+			}
+		}
+	}
+
+	return result.String(), nil
+}
+
+// return the argument in the given position and mark it as used
+func formatArgument(index int, args []obj.Object, used []bool) (obj.Object, *obj.Error) {
+	if index >= len(args) {
+		return nil, &obj.Error{
+			Message: fmt.Sprintf("faltan argumentos para formatear, se esperaban al menos %d, se recibieron %d", index+1, len(args)),
+		}
+	}
+
+	used[index] = true
+	return args[index], nil
+}
+
+// return the value of the key in the map passed as the last argument
+func namedFormatArgument(name string, args []obj.Object, used []bool) (obj.Object, *obj.Error) {
+	if len(args) != 0 {
+		if values, isMap := args[len(args)-1].(*obj.Map); isMap && values.Has(&obj.String{Value: name}) {
+			used[len(args)-1] = true
+			return values.Get(&obj.String{Value: name}), nil
+		}
+	}
+
+	return nil, &obj.Error{Message: fmt.Sprintf("no se encontro el argumento %s para formatear", name)}
+}
+
+func mixedFieldsError() *obj.Error {
+	return &obj.Error{Message: "no se pueden mezclar campos automaticos {} y numerados {0} en el formato"}
+}
+
+// check if the string is not empty and only has digits
+func isDigits(str string) bool {
+	if str == "" {
+		return false
+	}
+
+	for _, char := range str {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parse a specifier with the form [[fill]align][sign][0][width][,|_][.precision][type]
+func parseFormatSpec(specifier string) (*formatSpec, *obj.Error) {
+	spec := &formatSpec{fill: ' ', precision: -1}
+	invalid := &obj.Error{Message: fmt.Sprintf("especificador de formato no valido: %s", specifier)}
+	rest := specifier
+
+	if first, size := utf8.DecodeRuneInString(rest); size > 0 {
+		if second, secondSize := utf8.DecodeRuneInString(rest[size:]); isAlign(second) {
+			spec.fill, spec.align = first, second
+			rest = rest[size+secondSize:]
+		} else if isAlign(first) {
+			spec.align = first
+			rest = rest[size:]
+		}
+	}
+
+	if rest != "" && strings.ContainsRune("+- ", rune(rest[0])) {
+		spec.sign = rune(rest[0])
+		rest = rest[1:]
+	}
+
+	if strings.HasPrefix(rest, "0") {
+		spec.zero = true
+		rest = rest[1:]
+	}
+
+	digits := 0
+	for digits < len(rest) && isDigits(rest[digits:digits+1]) {
+		digits++
+	}
+
+	spec.width, _ = strconv.Atoi(rest[:digits])
+	rest = rest[digits:]
+
+	if rest != "" && (rest[0] == ',' || rest[0] == '_') {
+		spec.grouping = rest[:1]
+		rest = rest[1:]
+	}
+
+	if strings.HasPrefix(rest, ".") {
+		digits = 1
+		for digits < len(rest) && isDigits(rest[digits:digits+1]) {
+			digits++
+		}
+
+		if digits == 1 {
+			return nil, invalid
+		}
+
+		spec.precision, _ = strconv.Atoi(rest[1:digits])
+		rest = rest[digits:]
+	}
+
+	if len(rest) > 1 || (rest != "" && !strings.Contains("sdbxXoeEf%", rest)) {
+		return nil, invalid
+	}
+
+	if rest != "" {
+		spec.verb = rune(rest[0])
+	}
+
+	return spec, nil
+}
+
+func isAlign(char rune) bool {
+	return char == '<' || char == '>' || char == '^'
+}
+
+// format the value using the specifier
+func (s *formatSpec) format(value obj.Object) (string, *obj.Error) {
+	var formatted string
+	numeric := true
+
+	switch s.verb {
+	case 'd', 'b', 'o', 'x', 'X':
+		integer, isInt := obj.ToBigInt(value)
+		if !isInt {
+			return "", s.typeError(value)
+		}
+
+		formatted = integer.Text(map[rune]int{'d': 10, 'b': 2, 'o': 8, 'x': 16, 'X': 16}[s.verb])
+		if s.verb == 'X' {
+			formatted = strings.ToUpper(formatted)
+		}
+
+	case 'f', 'e', 'E', '%':
+		number, err := s.formatFloat(value)
+		if err != nil {
+			return "", err
+		}
+
+		formatted = number
+
+	case 's':
+		formatted, numeric = formatText(value, s.precision), false
+
+	default:
+		if !isFormatNumber(value) {
+			formatted, numeric = formatText(value, s.precision), false
+			break
+		}
+
+		if s.precision == -1 {
+			formatted = value.Inspect()
+			break
+		}
+
+		number, err := s.formatFloat(value)
+		if err != nil {
+			return "", err
+		}
+
+		formatted = number
+	}
+
+	if numeric {
+		formatted = s.applySign(formatted)
+		formatted = s.applyGrouping(formatted)
+	} else if s.sign != 0 || s.grouping != "" {
+		return "", &obj.Error{Message: "el signo y el separador de miles solo se pueden usar con numeros"}
+	}
+
+	return s.pad(formatted, numeric), nil
+}
+
+// format a number with decimals, the decimals and fractions are formatted
+// without losing precision
+func (s *formatSpec) formatFloat(value obj.Object) (string, *obj.Error) {
+	if !isFormatNumber(value) {
+		return "", s.typeError(value)
+	}
+
+	precision := s.precision
+	if precision == -1 {
+		precision = 6
+	}
+
+	exact, isExact := obj.ToRat(value)
+	if float, isFloat := value.(*obj.Float); isFloat {
+		// the NaN and infinite floats can not be represented exactly
+		exact = new(big.Rat).SetFloat64(float.Value)
+		isExact = exact != nil
+	}
+
+	switch {
+	case s.verb == '%' && isExact:
+		percent := new(big.Rat).Mul(exact, big.NewRat(100, 1))
+		return percent.FloatString(precision) + "%", nil
+
+	case s.verb == '%':
+		return strconv.FormatFloat(value.(*obj.Float).Value*100, 'f', precision, 64) + "%", nil
+
+	case s.verb == 'e' || s.verb == 'E':
+		float := exactToFloat64(value)
+		return strconv.FormatFloat(float, byte(s.verb), precision, 64), nil
+
+	case isExact:
+		return exact.FloatString(precision), nil
+
+	default:
+		// NaN and infinite floats
+		return strconv.FormatFloat(value.(*obj.Float).Value, 'f', precision, 64), nil
+	}
+}
+
+// add the sign to the number if the specifier asks for it
+func (s *formatSpec) applySign(number string) string {
+	if strings.HasPrefix(number, "-") {
+		return number
+	}
+
+	switch s.sign {
+	case '+':
+		return "+" + number
+	case ' ':
+		return " " + number
+	default:
+		return number
+	}
+}
+
+// insert the thousands separator in the integer part of the number
+func (s *formatSpec) applyGrouping(number string) string {
+	if s.grouping == "" {
+		return number
+	}
+
+	start := strings.IndexAny(number, "0123456789")
+	if start == -1 || (s.verb != 0 && !strings.ContainsRune("dfeE%", s.verb)) {
+		return number
+	}
+
+	end := start
+	for end < len(number) && number[end] >= '0' && number[end] <= '9' {
+		end++
+	}
+
+	digits := number[start:end]
+	var grouped strings.Builder
+	for idx, digit := range digits {
+		if idx != 0 && (len(digits)-idx)%3 == 0 {
+			grouped.WriteString(s.grouping)
+		}
+
+		grouped.WriteRune(digit)
+	}
+
+	return number[:start] + grouped.String() + number[end:]
+}
+
+// fill the value until it has the width of the specifier, the numbers are
+// aligned to the right and the rest of the values to the left
+func (s *formatSpec) pad(value string, numeric bool) string {
+	missing := s.width - utf8.RuneCountInString(value)
+	if missing <= 0 {
+		return value
+	}
+
+	if s.zero && s.align == 0 && numeric {
+		signLength := 0
+		if strings.ContainsAny(value[:1], "+- ") {
+			signLength = 1
+		}
+
+		return value[:signLength] + strings.Repeat("0", missing) + value[signLength:]
+	}
+
+	fill := string(s.fill)
+	if s.zero && s.align == 0 {
+		fill = "0"
+	}
+
+	align := s.align
+	if align == 0 && numeric {
+		align = '>'
+	}
+
+	switch align {
+	case '>':
+		return strings.Repeat(fill, missing) + value
+	case '^':
+		return strings.Repeat(fill, missing/2) + value + strings.Repeat(fill, missing-missing/2)
+	default:
+		return value + strings.Repeat(fill, missing)
+	}
+}
+
+func (s *formatSpec) typeError(value obj.Object) *obj.Error {
+	return &obj.Error{
+		Message: fmt.Sprintf("el formato %c no se puede usar con %s", s.verb, obj.Types[value.Type()]),
+	}
+}
+
+// return the text of the value, the precision truncates the text
+func formatText(value obj.Object, precision int) string {
+	text := value.Inspect()
+	if precision >= 0 && utf8.RuneCountInString(text) > precision {
+		return string([]rune(text)[:precision])
+	}
+
+	return text
+}
+
+// check if the value is any of the number types
+func isFormatNumber(value obj.Object) bool {
+	switch value.(type) {
+	case *obj.Number, *obj.BigInteger, *obj.Float, *obj.Decimal, *obj.Fraction:
+		return true
+	default:
+		return false
+	}
+}
+
+// return the value of any of the number types as a float
+func exactToFloat64(value obj.Object) float64 {
+	if float, isFloat := value.(*obj.Float); isFloat {
+		return float.Value
+	}
+
+	exact, _ := obj.ToRat(value)
+	float, _ := exact.Float64()
+	return float
+}
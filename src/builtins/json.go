@@ -0,0 +1,353 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// name of the method a class can define to be converted to json
+const jsonHook = "a_json"
+
+// generates the json module
+func newJSONModule() *obj.Module {
+	return obj.NewModule("json", map[string]obj.Object{
+		"decodificar": obj.NewBuiltin(jsonDecode),
+		"codificar":   obj.NewBuiltin(jsonEncode),
+	})
+}
+
+// convert a json text to aura objects
+func jsonDecode(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("decodificar", len(args), 1)
+	}
+
+	text, isStr := args[0].(*obj.String)
+	if !isStr {
+		return unsoportedArgumentType("decodificar", obj.Types[args[0].Type()])
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(text.Value))
+	decoder.UseNumber()
+
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return invalidJSON(decoder)
+	}
+
+	// the text must contain only one json value
+	if _, err := decoder.Token(); err != io.EOF {
+		return invalidJSON(decoder)
+	}
+
+	return value
+}
+
+// read the next json value from the decoder
+func decodeJSONValue(decoder *json.Decoder) (obj.Object, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		if value == '{' {
+			return decodeJSONObject(decoder)
+		}
+
+		if value == '[' {
+			return decodeJSONArray(decoder)
+		}
+
+		return nil, errors.New("delimitador inesperado")
+
+	case json.Number:
+		return decodeJSONNumber(value)
+
+	case string:
+		return &obj.String{Value: value}, nil
+
+	case bool:
+		if value {
+			return obj.SingletonTRUE, nil
+		}
+
+		return obj.SingletonFALSE, nil
+
+	default:
+		return obj.NullVAlue, nil
+	}
+}
+
+// read the key value pairs of a json object until the closing brace
+func decodeJSONObject(decoder *json.Decoder) (obj.Object, error) {
+	hashMap := obj.NewMap()
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, isStr := token.(string)
+		if !isStr {
+			return nil, errors.New("la llave debe ser un texto")
+		}
+
+		value, err := decodeJSONValue(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		hashMap.UpdateKey(&obj.String{Value: key}, value)
+	}
+
+	// consume the closing brace
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return hashMap, nil
+}
+
+// read the values of a json array until the closing bracket
+func decodeJSONArray(decoder *json.Decoder) (obj.Object, error) {
+	list := &obj.List{Values: []obj.Object{}}
+	for decoder.More() {
+		value, err := decodeJSONValue(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		list.Values = append(list.Values, value)
+	}
+
+	// consume the closing bracket
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// convert a json number to an integer if is possible or to a float, the
+// integers that do not fit in an int are decoded as big integers
+func decodeJSONNumber(number json.Number) (obj.Object, error) {
+	if integer, err := strconv.Atoi(number.String()); err == nil {
+		return &obj.Number{Value: integer}, nil
+	}
+
+	if integer, isInt := new(big.Int).SetString(number.String(), 10); isInt {
+		return obj.NewInteger(integer), nil
+	}
+
+	float, err := number.Float64()
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.NewFloat(float), nil
+}
+
+// return an error indicating where the json text is not valid
+func invalidJSON(decoder *json.Decoder) *obj.Error {
+	return &obj.Error{
+		Message: fmt.Sprintf("el texto no es un json valido, error cerca de la posicion %d", decoder.InputOffset()),
+	}
+}
+
+// convert an aura object to a json text
+func jsonEncode(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgsRange("codificar", len(args), 1, 2)
+	}
+
+	indent := false
+	if len(args) == 2 {
+		indentArg, isBool := args[1].(*obj.Bool)
+		if !isBool {
+			return unsoportedArgumentType("codificar", obj.Types[args[1].Type()])
+		}
+
+		indent = indentArg.Value
+	}
+
+	encoder := &jsonEncoder{visited: map[obj.Object]bool{}}
+	if err := encoder.encode(args[0]); err != nil {
+		return err
+	}
+
+	if !indent {
+		return &obj.String{Value: encoder.buf.String()}
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, encoder.buf.Bytes(), "", "  "); err != nil {
+		return &obj.Error{Message: "no se pudo indentar el json"}
+	}
+
+	return &obj.String{Value: indented.String()}
+}
+
+// represents the state while an object is converted to json
+type jsonEncoder struct {
+	buf     bytes.Buffer        // represents the json text
+	visited map[obj.Object]bool // represents the lists and maps that are being encoded
+}
+
+// write the json representation of the object in the buffer
+func (j *jsonEncoder) encode(value obj.Object) *obj.Error {
+	switch node := value.(type) {
+	case *obj.Number:
+		j.buf.WriteString(strconv.Itoa(node.Value))
+
+	case *obj.BigInteger:
+		j.buf.WriteString(node.Value.String())
+
+	case *obj.Float:
+		if math.IsNaN(node.Value) || math.IsInf(node.Value, 0) {
+			return &obj.Error{Message: fmt.Sprintf("no se puede convertir a json el flotante %s", node.Inspect())}
+		}
+
+		float := strconv.FormatFloat(node.Value, 'g', -1, 64)
+		if !strings.ContainsAny(float, ".e") {
+			float += ".0"
+		}
+		j.buf.WriteString(float)
+
+	case *obj.String:
+		j.encodeString(node.Value)
+
+	case *obj.Bool:
+		j.buf.WriteString(strconv.FormatBool(node.Value))
+
+	case *obj.Null:
+		j.buf.WriteString("null")
+
+	case *obj.List:
+		return j.encodeList(node)
+
+	case *obj.Tuple:
+		return j.encodeArray(node.Values)
+
+	case *obj.Map:
+		return j.encodeMap(node)
+
+	case *obj.ClassInstance:
+		return j.encodeClassInstance(node)
+
+	default:
+		return &obj.Error{
+			Message: fmt.Sprintf("no se puede convertir a json un objeto de tipo %s", obj.Types[value.Type()]),
+		}
+	}
+
+	return nil
+}
+
+// write a json string escaping the special characters
+func (j *jsonEncoder) encodeString(str string) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(str)
+	j.buf.Write(bytes.TrimRight(buf.Bytes(), "\n"))
+}
+
+// write a json array with the values of the list
+func (j *jsonEncoder) encodeList(list *obj.List) *obj.Error {
+	if err := j.enter(list); err != nil {
+		return err
+	}
+	defer delete(j.visited, list)
+
+	return j.encodeArray(list.Values)
+}
+
+// write a json array with the values, the tuples are written as arrays too
+func (j *jsonEncoder) encodeArray(values []obj.Object) *obj.Error {
+	j.buf.WriteByte('[')
+	for idx, value := range values {
+		if idx != 0 {
+			j.buf.WriteByte(',')
+		}
+
+		if err := j.encode(value); err != nil {
+			return err
+		}
+	}
+	j.buf.WriteByte(']')
+	return nil
+}
+
+// write a json object with the key value pairs of the map
+func (j *jsonEncoder) encodeMap(hashMap *obj.Map) *obj.Error {
+	if err := j.enter(hashMap); err != nil {
+		return err
+	}
+	defer delete(j.visited, hashMap)
+
+	// the keys are written as text, so keys like 1 and "1" would be repeated
+	names := make(map[string]bool, len(hashMap.Keys))
+	j.buf.WriteByte('{')
+	for idx, key := range hashMap.Keys {
+		if idx != 0 {
+			j.buf.WriteByte(',')
+		}
+
+		if names[key.Inspect()] {
+			return &obj.Error{
+				Message: fmt.Sprintf("no se puede convertir a json el mapa, la llave %s se repite como texto", key.Inspect()),
+			}
+		}
+
+		names[key.Inspect()] = true
+		j.encodeString(key.Inspect())
+		j.buf.WriteByte(':')
+		if err := j.encode(hashMap.Get(key)); err != nil {
+			return err
+		}
+	}
+	j.buf.WriteByte('}')
+	return nil
+}
+
+// write the value returned by the a_json method of the class
+func (j *jsonEncoder) encodeClassInstance(instance *obj.ClassInstance) *obj.Error {
+	hook, exists := instance.Env.Store[jsonHook]
+	if !exists {
+		return &obj.Error{
+			Message: fmt.Sprintf("no se puede convertir a json la clase %s, debe definir el metodo %s", instance.Name, jsonHook),
+		}
+	}
+
+	if err := j.enter(instance); err != nil {
+		return err
+	}
+	defer delete(j.visited, instance)
+
+	value := applyFunction(hook)
+	if err, isErr := value.(*obj.Error); isErr {
+		return err
+	}
+
+	return j.encode(value)
+}
+
+// mark the object as visited and check that is not already being encoded
+func (j *jsonEncoder) enter(value obj.Object) *obj.Error {
+	if j.visited[value] {
+		return &obj.Error{Message: "no se puede convertir a json un objeto que se contiene a si mismo"}
+	}
+
+	j.visited[value] = true
+	return nil
+}
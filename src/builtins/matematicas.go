@@ -0,0 +1,398 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// generates the matematicas module
+func newMathModule() *obj.Module {
+	return obj.NewModule("matematicas", map[string]obj.Object{
+		"pi":        obj.NewFloat(math.Pi),
+		"e":         obj.NewFloat(math.E),
+		"raiz":      obj.NewBuiltin(sqrt),
+		"potencia":  obj.NewBuiltin(pow),
+		"piso":      obj.NewBuiltin(floor),
+		"techo":     obj.NewBuiltin(ceil),
+		"redondear": obj.NewBuiltin(round),
+		"sen":       obj.NewBuiltin(floatFunction("sen", math.Sin)),
+		"cos":       obj.NewBuiltin(floatFunction("cos", math.Cos)),
+		"tan":       obj.NewBuiltin(floatFunction("tan", math.Tan)),
+		"asen":      obj.NewBuiltin(inverseTrigFunction("asen", math.Asin)),
+		"acos":      obj.NewBuiltin(inverseTrigFunction("acos", math.Acos)),
+		"atan":      obj.NewBuiltin(floatFunction("atan", math.Atan)),
+		"exp":       obj.NewBuiltin(floatFunction("exp", math.Exp)),
+		"log":       obj.NewBuiltin(logarithm),
+		"log10":     obj.NewBuiltin(logFunction("log10", math.Log10)),
+		"log2":      obj.NewBuiltin(logFunction("log2", math.Log2)),
+		"min":       obj.NewBuiltin(minMax("min", -1)),
+		"max":       obj.NewBuiltin(minMax("max", 1)),
+		"mcd":       obj.NewBuiltin(gcd),
+		"mcm":       obj.NewBuiltin(lcm),
+		"suma":      obj.NewBuiltin(sum),
+		"promedio":  obj.NewBuiltin(average),
+	})
+}
+
+// return the square root of the given number
+func sqrt(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("raiz", len(args), 1)
+	}
+
+	val, isNum := toFloat(args[0])
+	if !isNum {
+		return unsoportedArgumentType("raiz", obj.Types[args[0].Type()])
+	}
+
+	if val < 0 {
+		return &obj.Error{Message: "no se puede calcular la raiz de un numero negativo"}
+	}
+
+	return obj.NewFloat(math.Sqrt(val))
+}
+
+// return the base raised to the exponent. if both are integers and the
+// exponent is positive the result is an integer that is promoted to a big
+// integer if it overflows, like the ** operator
+func pow(args ...obj.Object) obj.Object {
+	if len(args) != 2 {
+		return wrongNumberofArgs("potencia", len(args), 2)
+	}
+
+	base, isInt := obj.ToBigInt(args[0])
+	exponent, isExpInt := obj.ToBigInt(args[1])
+	if isInt && isExpInt && exponent.Sign() >= 0 {
+		if obj.PowerTooLarge(base, exponent) {
+			return &obj.Error{Message: "el resultado de la potencia es demasiado grande"}
+		}
+
+		// big.Int.Exp uses exponentiation by squaring
+		return obj.NewInteger(new(big.Int).Exp(base, exponent, nil))
+	}
+
+	for _, arg := range args {
+		if _, isNum := toFloat(arg); !isNum {
+			return unsoportedArgumentType("potencia", obj.Types[arg.Type()])
+		}
+	}
+
+	baseVal, _ := toFloat(args[0])
+	expVal, _ := toFloat(args[1])
+	return obj.NewFloat(math.Pow(baseVal, expVal))
+}
+
+// return the greatest integer less than or equal to the number
+func floor(args ...obj.Object) obj.Object {
+	return roundToInt("piso", math.Floor, args)
+}
+
+// return the least integer greater than or equal to the number
+func ceil(args ...obj.Object) obj.Object {
+	return roundToInt("techo", math.Ceil, args)
+}
+
+// round the number to the nearest integer or to the given number of decimals,
+// the optional third argument is the rounding mode
+func round(args ...obj.Object) obj.Object {
+	if len(args) == 1 {
+		return roundToInt("redondear", math.Round, args)
+	}
+
+	if len(args) != 2 && len(args) != 3 {
+		return wrongNumberofArgsRange("redondear", len(args), 1, 3)
+	}
+
+	decimals, err := intArgument("redondear", args[1])
+	if err != nil {
+		return err
+	}
+
+	mode := obj.HALFUP
+	if len(args) == 3 {
+		name, isStr := args[2].(*obj.String)
+		if !isStr {
+			return unsoportedArgumentType("redondear", obj.Types[args[2].Type()])
+		}
+
+		roundingMode, exists := obj.RoundingModes[name.Value]
+		if !exists {
+			return &obj.Error{Message: fmt.Sprintf("modo de redondeo desconocido %s", name.Value)}
+		}
+
+		mode = roundingMode
+	}
+
+	switch arg := args[0].(type) {
+	case *obj.Decimal:
+		scale := decimals
+		if scale < 0 {
+			scale = 0
+		}
+
+		return obj.NewDecimal(obj.RoundRat(arg.Value, decimals, mode), scale)
+
+	case *obj.Fraction:
+		return obj.NewFraction(obj.RoundRat(arg.Value, decimals, mode))
+
+	case *obj.Float:
+		// the float is rounded as it is written, so 2.675 is rounded to 2.68
+		value, _ := new(big.Rat).SetString(strconv.FormatFloat(arg.Value, 'g', -1, 64))
+		rounded, _ := obj.RoundRat(value, decimals, mode).Float64()
+		return obj.NewFloat(rounded)
+
+	default:
+		value, isExact := obj.ToRat(args[0])
+		if !isExact {
+			return unsoportedArgumentType("redondear", obj.Types[args[0].Type()])
+		}
+
+		return obj.NewInteger(obj.RoundRat(value, decimals, mode).Num())
+	}
+}
+
+// apply the rounding function to the argument and return an integer
+func roundToInt(funcName string, fn func(float64) float64, args []obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs(funcName, len(args), 1)
+	}
+
+	switch arg := args[0].(type) {
+	case *obj.Number:
+		return &obj.Number{Value: arg.Value}
+
+	case *obj.BigInteger:
+		return arg
+
+	case *obj.Float:
+		return floatToInteger(funcName, fn(arg.Value))
+
+	case *obj.Decimal, *obj.Fraction:
+		value, _ := obj.ToRat(arg)
+		return obj.NewInteger(obj.RoundRat(value, 0, roundingFunctions[funcName]).Num())
+
+	default:
+		return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+	}
+}
+
+// the rounding mode of the exact numbers for each rounding function
+var roundingFunctions = map[string]obj.RoundingMode{
+	"piso":      obj.FLOOR,
+	"techo":     obj.CEILING,
+	"redondear": obj.HALFUP,
+}
+
+// generates a builtin that applies the given function to a single number
+func floatFunction(funcName string, fn func(float64) float64) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		val, isNum := toFloat(args[0])
+		if !isNum {
+			return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+		}
+
+		return obj.NewFloat(fn(val))
+	}
+}
+
+// generates a builtin for the inverse trigonometric functions that are only
+// defined between -1 and 1
+func inverseTrigFunction(funcName string, fn func(float64) float64) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		val, isNum := toFloat(args[0])
+		if !isNum {
+			return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+		}
+
+		if val < -1 || val > 1 {
+			return &obj.Error{Message: fmt.Sprintf("%s solo acepta valores entre -1 y 1", funcName)}
+		}
+
+		return obj.NewFloat(fn(val))
+	}
+}
+
+// generates a builtin for the logarithm functions that are only defined
+// for positive numbers
+func logFunction(funcName string, fn func(float64) float64) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		val, isNum := toFloat(args[0])
+		if !isNum {
+			return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+		}
+
+		if val <= 0 {
+			return &obj.Error{Message: fmt.Sprintf("%s solo acepta numeros mayores a 0", funcName)}
+		}
+
+		return obj.NewFloat(fn(val))
+	}
+}
+
+// return the natural logarithm of the number or the logarithm in the given base
+func logarithm(args ...obj.Object) obj.Object {
+	if len(args) == 1 {
+		return logFunction("log", math.Log)(args...)
+	}
+
+	if len(args) != 2 {
+		return wrongNumberofArgs("log", len(args), 2)
+	}
+
+	val := logFunction("log", math.Log)(args[0])
+	if _, isErr := val.(*obj.Error); isErr {
+		return val
+	}
+
+	base := logFunction("log", math.Log)(args[1])
+	if _, isErr := base.(*obj.Error); isErr {
+		return base
+	}
+
+	if base.(*obj.Float).Value == 0 {
+		return &obj.Error{Message: "la base del logaritmo no puede ser 1"}
+	}
+
+	return obj.NewFloat(val.(*obj.Float).Value / base.(*obj.Float).Value)
+}
+
+// generates the min or max builtin. the builtins recibe a list or
+// many numbers, the sign indicates if we look for the min or the max
+func minMax(funcName string, sign float64) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		values := args
+		if len(args) == 1 {
+			list, isList := args[0].(*obj.List)
+			if !isList {
+				return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+			}
+
+			values = list.Values
+		}
+
+		if len(values) == 0 {
+			return &obj.Error{Message: fmt.Sprintf("%s necesita al menos un valor", funcName)}
+		}
+
+		var result obj.Object
+		for _, value := range values {
+			if _, isNum := toFloat(value); !isNum {
+				return unsoportedArgumentType(funcName, obj.Types[value.Type()])
+			}
+
+			if result == nil || float64(compareNumbers(value, result))*sign > 0 {
+				result = value
+			}
+		}
+
+		return result
+	}
+}
+
+// return the greatest common divisor of two integers
+func gcd(args ...obj.Object) obj.Object {
+	a, b, err := integerPair("mcd", args)
+	if err != nil {
+		return err
+	}
+
+	// the divisor is always positive, even with negative integers
+	return obj.NewInteger(new(big.Int).GCD(nil, nil, a, b))
+}
+
+// return the least common multiple of two integers
+func lcm(args ...obj.Object) obj.Object {
+	a, b, err := integerPair("mcm", args)
+	if err != nil {
+		return err
+	}
+
+	if a.Sign() == 0 || b.Sign() == 0 {
+		return &obj.Number{Value: 0}
+	}
+
+	result := new(big.Int).Div(a, new(big.Int).GCD(nil, nil, a, b))
+	result.Mul(result, b)
+	return obj.NewInteger(result.Abs(result))
+}
+
+// check that the args are two integers and return their values
+func integerPair(funcName string, args []obj.Object) (*big.Int, *big.Int, *obj.Error) {
+	if len(args) != 2 {
+		return nil, nil, wrongNumberofArgs(funcName, len(args), 2)
+	}
+
+	values := make([]*big.Int, 0, 2)
+	for _, arg := range args {
+		value, isInt := obj.ToBigInt(arg)
+		if !isInt {
+			return nil, nil, unsoportedArgumentType(funcName, obj.Types[arg.Type()])
+		}
+
+		values = append(values, value)
+	}
+
+	return values[0], values[1], nil
+}
+
+// compare two integers or floats, the integers are compared without
+// converting them to floats so the big integers keep their precision
+func compareNumbers(left, right obj.Object) int {
+	leftInt, isLeftInt := obj.ToBigInt(left)
+	rightInt, isRightInt := obj.ToBigInt(right)
+	if isLeftInt && isRightInt {
+		return leftInt.Cmp(rightInt)
+	}
+
+	leftVal, _ := toFloat(left)
+	rightVal, _ := toFloat(right)
+	switch {
+	case leftVal < rightVal:
+		return -1
+
+	case leftVal > rightVal:
+		return 1
+
+	default:
+		return 0
+	}
+}
+
+// return the average of the numbers in a list
+func average(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("promedio", len(args), 1)
+	}
+
+	list, isList := args[0].(*obj.List)
+	if !isList {
+		return unsoportedArgumentType("promedio", obj.Types[args[0].Type()])
+	}
+
+	if len(list.Values) == 0 {
+		return &obj.Error{Message: "promedio necesita al menos un valor"}
+	}
+
+	total := sumValues("promedio", list.Values)
+	if _, isErr := total.(*obj.Error); isErr {
+		return total
+	}
+
+	val, _ := toFloat(total)
+	return obj.NewFloat(val / float64(len(list.Values)))
+}
@@ -2,6 +2,8 @@ package builtins
 
 import (
 	obj "aura/src/object"
+	"fmt"
+	"unicode/utf8"
 )
 
 func add(args ...obj.Object) obj.Object {
@@ -9,11 +11,7 @@ func add(args ...obj.Object) obj.Object {
 		return wrongNumberofArgs("agregar", len(args), 1)
 	}
 
-	if num, isNumber := args[0].(*obj.Number); isNumber {
-		return obj.NewMethod(num, obj.APPEND)
-	}
-
-	return unsoportedArgumentType("add", obj.Types[args[0].Type()])
+	return obj.NewMethod(args[0], obj.APPEND)
 }
 
 func remove(args ...obj.Object) obj.Object {
@@ -21,11 +19,11 @@ func remove(args ...obj.Object) obj.Object {
 		return wrongNumberofArgs("popIndice", len(args), 1)
 	}
 
-	if num, isNumber := args[0].(*obj.Number); isNumber {
-		return obj.NewMethod(num, obj.REMOVE)
+	if _, err := intArgument("popIndice", args[0]); err != nil {
+		return err
 	}
 
-	return unsoportedArgumentType("popIndice", obj.Types[args[0].Type()])
+	return obj.NewMethod(args[0], obj.REMOVE)
 }
 
 func pop(args ...obj.Object) obj.Object {
@@ -122,8 +120,8 @@ func filter(args ...obj.Object) obj.Object {
 	}
 
 	if fn, isFn := args[0].(*obj.Def); isFn {
-		if len(fn.Parameters) != 1 {
-			return &obj.Error{Message: "La funcion filtrar solo puede recibir un argumento"}
+		if len(fn.Parameters) != 1 && len(fn.Parameters) != 2 {
+			return &obj.Error{Message: "La funcion filtrar solo puede recibir uno o dos argumentos"}
 		}
 
 		return obj.NewMethod(fn, obj.FILTER)
@@ -159,3 +157,357 @@ func split(args ...obj.Object) obj.Object {
 
 	return unsoportedArgumentType("separar", obj.Types[args[0].Type()])
 }
+
+// return a method builtin that does not recibe arguments
+func methodWithoutArgs(funcName string, methodType obj.MethodsTypes) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 0 {
+			return wrongNumberofArgs(funcName, len(args), 0)
+		}
+
+		return obj.NewMethod(obj.SingletonNUll, methodType)
+	}
+}
+
+// return a method builtin that recibe a string argument
+func methodWithString(funcName string, methodType obj.MethodsTypes) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		if str, isStr := args[0].(*obj.String); isStr {
+			return obj.NewMethod(str, methodType)
+		}
+
+		return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+	}
+}
+
+// remove the white spaces or the given characters from both sides of the string
+func trim(args ...obj.Object) obj.Object {
+	if len(args) > 1 {
+		return wrongNumberofArgs("recortar", len(args), 1)
+	}
+
+	if len(args) == 0 {
+		return obj.NewMethod(obj.SingletonNUll, obj.TRIM)
+	}
+
+	if str, isStr := args[0].(*obj.String); isStr {
+		return obj.NewMethod(str, obj.TRIM)
+	}
+
+	return unsoportedArgumentType("recortar", obj.Types[args[0].Type()])
+}
+
+// replace the old text with the new one, an optional number indicates
+// how many replacements are made
+func replace(args ...obj.Object) obj.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return wrongNumberofArgs("reemplazar", len(args), 3)
+	}
+
+	for _, arg := range args[:2] {
+		if _, isStr := arg.(*obj.String); !isStr {
+			return unsoportedArgumentType("reemplazar", obj.Types[arg.Type()])
+		}
+	}
+
+	if len(args) == 3 {
+		if _, err := intArgument("reemplazar", args[2]); err != nil {
+			return err
+		}
+	}
+
+	return obj.NewMethod(&obj.List{Values: args}, obj.REPLACE)
+}
+
+// repeat the string the given number of times
+func repeat(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("repetir", len(args), 1)
+	}
+
+	times, err := intArgument("repetir", args[0])
+	if err != nil {
+		return err
+	}
+
+	if times < 0 {
+		return &obj.Error{Message: "no se puede repetir un texto un numero negativo de veces"}
+	}
+
+	return obj.NewMethod(args[0], obj.REPEAT)
+}
+
+// join the values of the list using the string as separator
+func join(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("unir", len(args), 1)
+	}
+
+	if list, isList := args[0].(*obj.List); isList {
+		return obj.NewMethod(list, obj.JOIN)
+	}
+
+	return unsoportedArgumentType("unir", obj.Types[args[0].Type()])
+}
+
+// return a method builtin that fill the string until the given length,
+// the fill character is a space by default
+func padMethod(funcName string, methodType obj.MethodsTypes) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 && len(args) != 2 {
+			return wrongNumberofArgs(funcName, len(args), 2)
+		}
+
+		if _, err := intArgument(funcName, args[0]); err != nil {
+			return err
+		}
+
+		fill := &obj.String{Value: " "}
+		if len(args) == 2 {
+			str, isStr := args[1].(*obj.String)
+			if !isStr || utf8.RuneCountInString(str.Value) != 1 {
+				return &obj.Error{Message: fmt.Sprintf("el relleno para %s debe ser un solo caracter", funcName)}
+			}
+
+			fill = str
+		}
+
+		return obj.NewMethod(&obj.List{Values: []obj.Object{args[0], fill}}, methodType)
+	}
+}
+
+// replace the {} in the string with the arguments
+func format(args ...obj.Object) obj.Object {
+	return obj.NewMethod(&obj.List{Values: args}, obj.FORMAT)
+}
+
+// return the characters between the start and the end, if the end
+// is not given the characters until the end of the string are returned
+func slice(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgs("rebanar", len(args), 2)
+	}
+
+	for _, arg := range args {
+		if _, err := intArgument("rebanar", arg); err != nil {
+			return err
+		}
+	}
+
+	return obj.NewMethod(&obj.List{Values: args}, obj.SLICE)
+}
+
+// check that the argument is a function with the given number of parameters
+func functionArg(funcName string, arg obj.Object, params int) (*obj.Def, *obj.Error) {
+	fn, isFn := arg.(*obj.Def)
+	if !isFn {
+		return nil, &obj.Error{Message: fmt.Sprintf("se requiere una funcion para %s", funcName)}
+	}
+
+	if len(fn.Parameters) != params {
+		return nil, &obj.Error{
+			Message: fmt.Sprintf("la funcion para %s debe recibir %d argumentos", funcName, params),
+		}
+	}
+
+	return fn, nil
+}
+
+// return a method builtin that recibe a function with the given number of parameters
+func methodWithFunction(funcName string, methodType obj.MethodsTypes, params int) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		fn, err := functionArg(funcName, args[0], params)
+		if err != nil {
+			return err
+		}
+
+		return obj.NewMethod(fn, methodType)
+	}
+}
+
+// sort the list, the arguments are an optional function that returns the
+// value used to compare and an optional flag to sort in reverse order
+func sortList(args ...obj.Object) obj.Object {
+	if len(args) > 2 {
+		return wrongNumberofArgs("ordenar", len(args), 2)
+	}
+
+	var key obj.Object = obj.NullVAlue
+	reverse := obj.SingletonFALSE
+	for idx, arg := range args {
+		switch arg := arg.(type) {
+		case *obj.Def:
+			if idx != 0 {
+				return &obj.Error{Message: "la funcion para ordenar debe ser el primer argumento"}
+			}
+
+			fn, err := functionArg("ordenar", arg, 1)
+			if err != nil {
+				return err
+			}
+
+			key = fn
+
+		case *obj.Bool:
+			reverse = arg
+
+		default:
+			return unsoportedArgumentType("ordenar", obj.Types[arg.Type()])
+		}
+	}
+
+	return obj.NewMethod(&obj.List{Values: []obj.Object{key, reverse}}, obj.SORT)
+}
+
+// insert the value in the given index
+func insert(args ...obj.Object) obj.Object {
+	if len(args) != 2 {
+		return wrongNumberofArgs("insertar", len(args), 2)
+	}
+
+	if _, err := intArgument("insertar", args[0]); err != nil {
+		return err
+	}
+
+	return obj.NewMethod(&obj.List{Values: args}, obj.INSERT)
+}
+
+// add all the values of other list at the end of the list
+func extend(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("extender", len(args), 1)
+	}
+
+	if list, isList := args[0].(*obj.List); isList {
+		return obj.NewMethod(list, obj.EXTEND)
+	}
+
+	return unsoportedArgumentType("extender", obj.Types[args[0].Type()])
+}
+
+// reduce the list to a single value calling the function with the
+// accumulated value and each element, the initial value is optional
+func reduce(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgs("reducir", len(args), 2)
+	}
+
+	if _, err := functionArg("reducir", args[0], 2); err != nil {
+		return err
+	}
+
+	return obj.NewMethod(&obj.List{Values: args}, obj.REDUCE)
+}
+
+// combine the list with the other lists in a list of lists
+func zip(args ...obj.Object) obj.Object {
+	if len(args) == 0 {
+		return wrongNumberofArgs("zip", len(args), 1)
+	}
+
+	for _, arg := range args {
+		if _, isList := arg.(*obj.List); !isList {
+			return unsoportedArgumentType("zip", obj.Types[arg.Type()])
+		}
+	}
+
+	return obj.NewMethod(&obj.List{Values: args}, obj.ZIP)
+}
+
+// return a method builtin that recibe an optional integer
+func methodWithOptionalInt(funcName string, methodType obj.MethodsTypes) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) > 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		if len(args) == 0 {
+			return obj.NewMethod(obj.NullVAlue, methodType)
+		}
+
+		if _, err := intArgument(funcName, args[0]); err != nil {
+			return err
+		}
+
+		return obj.NewMethod(args[0], methodType)
+	}
+}
+
+// return a method builtin that recibe an optional function with one parameter
+func methodWithOptionalFunction(funcName string, methodType obj.MethodsTypes) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) > 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		if len(args) == 0 {
+			return obj.NewMethod(obj.NullVAlue, methodType)
+		}
+
+		fn, err := functionArg(funcName, args[0], 1)
+		if err != nil {
+			return err
+		}
+
+		return obj.NewMethod(fn, methodType)
+	}
+}
+
+// return a method builtin that recibe a map
+func methodWithMap(funcName string, methodType obj.MethodsTypes) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		if hashMap, isMap := args[0].(*obj.Map); isMap {
+			return obj.NewMethod(hashMap, methodType)
+		}
+
+		return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+	}
+}
+
+// return a method builtin that recibe a set
+func methodWithSet(funcName string, methodType obj.MethodsTypes) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		if set, isSet := args[0].(*obj.Set); isSet {
+			return obj.NewMethod(set, methodType)
+		}
+
+		return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+	}
+}
+
+// return a method builtin that recibe any value
+func methodWithValue(funcName string, methodType obj.MethodsTypes) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		return obj.NewMethod(args[0], methodType)
+	}
+}
+
+// return the value of the key or the default value if the key not exists,
+// the default value is nulo if is not given
+func get(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgs("obtener", len(args), 2)
+	}
+
+	return obj.NewMethod(&obj.List{Values: args}, obj.GET)
+}
@@ -0,0 +1,44 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+)
+
+// signature for the functions that generate a standard library module
+type moduleLoader func() *obj.Module
+
+// signature for the function that call aura functions from the builtins
+type ApplyFunc func(fn obj.Object, args ...obj.Object) obj.Object
+
+// represents the function used by the modules to call aura functions,
+// the evaluator set it when is initialized
+var applyFunction ApplyFunc
+
+// set the function used by the modules to call aura functions
+func SetApplyFunction(fn ApplyFunc) {
+	applyFunction = fn
+}
+
+// all the modules in the standard library that can be imported with
+// the importar statement like:
+//		importar "matematicas"
+var MODULES = map[string]moduleLoader{
+	"matematicas": newMathModule,
+	"aleatorio":   newRandomModule,
+	"archivos":    newFilesModule,
+	"json":        newJSONModule,
+	"csv":         newCSVModule,
+	"regex":       newRegexModule,
+	"tiempo":      newTimeModule,
+	"sistema":     newSystemModule,
+}
+
+// return a new instance of the module with the given name if exists
+func LoadModule(name string) (*obj.Module, bool) {
+	loader, exists := MODULES[name]
+	if !exists {
+		return nil, false
+	}
+
+	return loader(), true
+}
@@ -0,0 +1,264 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// generates the regex module
+func newRegexModule() *obj.Module {
+	return obj.NewModule("regex", map[string]obj.Object{
+		"compilar": obj.NewBuiltin(compileRegex),
+	})
+}
+
+// compile the pattern and return a regex object like:
+//		patron := regex.compilar("(?P<dia>\d+)/(?P<mes>\d+)")
+func compileRegex(args ...obj.Object) obj.Object {
+	if len(args) != 1 {
+		return wrongNumberofArgs("compilar", len(args), 1)
+	}
+
+	pattern, isStr := args[0].(*obj.String)
+	if !isStr {
+		return unsoportedArgumentType("compilar", obj.Types[args[0].Type()])
+	}
+
+	re, err := regexp.Compile(pattern.Value)
+	if err != nil {
+		return &obj.Error{Message: fmt.Sprintf("el patron %s no es valido", pattern.Value)}
+	}
+
+	return newRegex(re)
+}
+
+// represents a compiled pattern, the methods of the regex object are
+// the methods of this struct
+type compiledRegex struct {
+	re *regexp.Regexp
+}
+
+// generates the regex object with its methods
+func newRegex(re *regexp.Regexp) *obj.Regex {
+	compiled := &compiledRegex{re: re}
+
+	return obj.NewRegex(re.String(), map[string]obj.Object{
+		"patron":       &obj.String{Value: re.String()},
+		"coincide":     obj.NewBuiltin(compiled.matches),
+		"buscar":       obj.NewBuiltin(compiled.find),
+		"buscar_todos": obj.NewBuiltin(compiled.findAll),
+		"reemplazar":   obj.NewBuiltin(compiled.replace),
+		"dividir":      obj.NewBuiltin(compiled.split),
+	})
+}
+
+// check if the pattern is found in the text
+func (c *compiledRegex) matches(args ...obj.Object) obj.Object {
+	text, err := textArg("coincide", args, 1)
+	if err != nil {
+		return err
+	}
+
+	if c.re.MatchString(text) {
+		return obj.SingletonTRUE
+	}
+
+	return obj.SingletonFALSE
+}
+
+// return the first match of the pattern in the text or nulo if there is none
+func (c *compiledRegex) find(args ...obj.Object) obj.Object {
+	text, err := textArg("buscar", args, 1)
+	if err != nil {
+		return err
+	}
+
+	loc := c.re.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return obj.NullVAlue
+	}
+
+	return c.newMatch(text, loc)
+}
+
+// return a list with all the matches of the pattern in the text
+func (c *compiledRegex) findAll(args ...obj.Object) obj.Object {
+	text, err := textArg("buscar_todos", args, 1)
+	if err != nil {
+		return err
+	}
+
+	matches := &obj.List{Values: []obj.Object{}}
+	for _, loc := range c.re.FindAllStringSubmatchIndex(text, -1) {
+		matches.Values = append(matches.Values, c.newMatch(text, loc))
+	}
+
+	return matches
+}
+
+// replace all the matches in the text. the replacement can be a text with
+// references to the groups like $1 or ${nombre}, or a function that recibe
+// the match and return the replacement text
+func (c *compiledRegex) replace(args ...obj.Object) obj.Object {
+	if len(args) != 2 {
+		return wrongNumberofArgs("reemplazar", len(args), 2)
+	}
+
+	text, err := textArg("reemplazar", args[:1], 1)
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	last := 0
+	for _, loc := range c.re.FindAllStringSubmatchIndex(text, -1) {
+		buf.WriteString(text[last:loc[0]])
+		last = loc[1]
+
+		switch replacement := args[1].(type) {
+		case *obj.String:
+			buf.Write(c.re.ExpandString(nil, replacement.Value, text, loc))
+
+		case *obj.Def, *obj.Builtin:
+			result := applyFunction(replacement, c.newMatch(text, loc))
+			if err, isErr := result.(*obj.Error); isErr {
+				return err
+			}
+
+			str, isStr := result.(*obj.String)
+			if !isStr {
+				return &obj.Error{
+					Message: fmt.Sprintf(
+						"la funcion de reemplazo debe regresar un texto, se recibio %s",
+						obj.Types[result.Type()],
+					),
+				}
+			}
+
+			buf.WriteString(str.Value)
+
+		default:
+			return unsoportedArgumentType("reemplazar", obj.Types[args[1].Type()])
+		}
+	}
+
+	buf.WriteString(text[last:])
+	return &obj.String{Value: buf.String()}
+}
+
+// split the text in the parts between the matches, an optional limit
+// indicates the max number of parts
+func (c *compiledRegex) split(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgs("dividir", len(args), 2)
+	}
+
+	text, err := textArg("dividir", args[:1], 1)
+	if err != nil {
+		return err
+	}
+
+	limit := -1
+	if len(args) == 2 {
+		if limit, err = intArgument("dividir", args[1]); err != nil {
+			return err
+		}
+	}
+
+	return stringsToList(c.re.Split(text, limit))
+}
+
+// generates a match object from the indexes returned by the regexp package.
+// the positions of the match are counted in characters not in bytes
+func (c *compiledRegex) newMatch(text string, loc []int) *obj.Match {
+	groups := &obj.List{Values: []obj.Object{}}
+	named := obj.NewMap()
+	for idx, name := range c.re.SubexpNames() {
+		if idx == 0 {
+			continue
+		}
+
+		group := submatch(text, loc, idx)
+		groups.Values = append(groups.Values, group)
+		if name != "" {
+			named.UpdateKey(&obj.String{Value: name}, group)
+		}
+	}
+
+	matched := text[loc[0]:loc[1]]
+	return obj.NewMatch(matched, map[string]obj.Object{
+		"texto":     &obj.String{Value: matched},
+		"inicio":    &obj.Number{Value: utf8.RuneCountInString(text[:loc[0]])},
+		"fin":       &obj.Number{Value: utf8.RuneCountInString(text[:loc[1]])},
+		"grupos":    groups,
+		"nombrados": named,
+		"grupo":     obj.NewBuiltin(c.group(text, loc)),
+	})
+}
+
+// return the function to get a group of the match by its number or its name,
+// the group 0 is all the match
+func (c *compiledRegex) group(text string, loc []int) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs("grupo", len(args), 1)
+		}
+
+		switch group := args[0].(type) {
+		case *obj.Number:
+			if group.Value < 0 || group.Value > c.re.NumSubexp() {
+				return &obj.Error{Message: fmt.Sprintf("el grupo %d no existe", group.Value)}
+			}
+
+			return submatch(text, loc, group.Value)
+
+		case *obj.String:
+			idx := c.re.SubexpIndex(group.Value)
+			if idx == -1 {
+				return &obj.Error{Message: fmt.Sprintf("el grupo %s no existe", group.Value)}
+			}
+
+			return submatch(text, loc, idx)
+
+		default:
+			return unsoportedArgumentType("grupo", obj.Types[args[0].Type()])
+		}
+	}
+}
+
+// return the text of the group or nulo if the group did not participate in the match
+func submatch(text string, loc []int, group int) obj.Object {
+	start, end := loc[2*group], loc[2*group+1]
+	if start == -1 {
+		return obj.NullVAlue
+	}
+
+	return &obj.String{Value: text[start:end]}
+}
+
+// check the number of args and return the text in the first argument
+func textArg(funcName string, args []obj.Object, expected int) (string, *obj.Error) {
+	if len(args) != expected {
+		return "", wrongNumberofArgs(funcName, len(args), expected)
+	}
+
+	text, isStr := args[0].(*obj.String)
+	if !isStr {
+		return "", unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+	}
+
+	return text.Value, nil
+}
+
+// generates a list of strings objects
+func stringsToList(values []string) *obj.List {
+	list := &obj.List{Values: make([]obj.Object, 0, len(values))}
+	for _, value := range values {
+		list.Values = append(list.Values, &obj.String{Value: value})
+	}
+
+	return list
+}
@@ -0,0 +1,136 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// represents the command line arguments given to the script after its path
+var scriptArgs []string
+
+// set the command line arguments returned by sistema.argumentos
+func SetScriptArgs(args []string) {
+	scriptArgs = args
+}
+
+// generates the sistema module
+func newSystemModule() *obj.Module {
+	return obj.NewModule("sistema", map[string]obj.Object{
+		"argumentos": obj.NewBuiltin(arguments),
+		"entorno":    obj.NewBuiltin(environmentVar),
+		"salir":      obj.NewBuiltin(exit),
+		"plataforma": obj.NewBuiltin(platform),
+		"ejecutar":   obj.NewBuiltin(runCommand),
+	})
+}
+
+// return a list with the command line arguments given to the script
+func arguments(args ...obj.Object) obj.Object {
+	if len(args) != 0 {
+		return wrongNumberofArgs("argumentos", len(args), 0)
+	}
+
+	return stringsToList(scriptArgs)
+}
+
+// return the value of the environment variable, if the variable does not
+// exists return the default value or nulo
+func environmentVar(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgsRange("entorno", len(args), 1, 2)
+	}
+
+	name, err := textArg("entorno", args[:1], 1)
+	if err != nil {
+		return err
+	}
+
+	if value, exists := os.LookupEnv(name); exists {
+		return &obj.String{Value: value}
+	}
+
+	if len(args) == 2 {
+		return args[1]
+	}
+
+	return obj.NullVAlue
+}
+
+// finish the process with the given exit code
+func exit(args ...obj.Object) obj.Object {
+	if len(args) > 1 {
+		return wrongNumberofArgs("salir", len(args), 1)
+	}
+
+	code := 0
+	if len(args) == 1 {
+		var err *obj.Error
+		if code, err = intArgument("salir", args[0]); err != nil {
+			return err
+		}
+	}
+
+	os.Exit(code)
+	return obj.SingletonNUll
+}
+
+// return the name of the operating system like linux, windows or darwin
+func platform(args ...obj.Object) obj.Object {
+	if len(args) != 0 {
+		return wrongNumberofArgs("plataforma", len(args), 0)
+	}
+
+	return &obj.String{Value: runtime.GOOS}
+}
+
+// run the command with the given arguments and wait until finish, return
+// a map with the output, the errors and the exit code like:
+//		mapa{"salida" => "...", "errores" => "...", "codigo" => 0}
+func runCommand(args ...obj.Object) obj.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return wrongNumberofArgsRange("ejecutar", len(args), 1, 2)
+	}
+
+	name, err := textArg("ejecutar", args[:1], 1)
+	if err != nil {
+		return err
+	}
+
+	var cmdArgs []string
+	if len(args) == 2 {
+		list, isList := args[1].(*obj.List)
+		if !isList {
+			return unsoportedArgumentType("ejecutar", obj.Types[args[1].Type()])
+		}
+
+		for _, arg := range list.Values {
+			cmdArgs = append(cmdArgs, arg.Inspect())
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, cmdArgs...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	code := 0
+	if runErr := cmd.Run(); runErr != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(runErr, &exitErr) {
+			return &obj.Error{Message: fmt.Sprintf("no se pudo ejecutar el comando %s", name)}
+		}
+
+		code = exitErr.ExitCode()
+	}
+
+	result := obj.NewMap()
+	result.UpdateKey(&obj.String{Value: "salida"}, &obj.String{Value: stdout.String()})
+	result.UpdateKey(&obj.String{Value: "errores"}, &obj.String{Value: stderr.String()})
+	result.UpdateKey(&obj.String{Value: "codigo"}, &obj.Number{Value: code})
+	return result
+}
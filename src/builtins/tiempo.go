@@ -0,0 +1,462 @@
+package builtins
+
+import (
+	obj "aura/src/object"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// names of the months and week days used to format and parse dates
+var (
+	monthNames = [...]string{
+		"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio",
+		"agosto", "septiembre", "octubre", "noviembre", "diciembre",
+	}
+
+	dayNames = [...]string{
+		"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado",
+	}
+)
+
+// generates the tiempo module
+func newTimeModule() *obj.Module {
+	return obj.NewModule("tiempo", map[string]obj.Object{
+		"ahora":        obj.NewBuiltin(now),
+		"fecha":        obj.NewBuiltin(newDateFromArgs),
+		"analizar":     obj.NewBuiltin(parseDate),
+		"dias":         obj.NewBuiltin(durationOf("dias", 24*time.Hour)),
+		"horas":        obj.NewBuiltin(durationOf("horas", time.Hour)),
+		"minutos":      obj.NewBuiltin(durationOf("minutos", time.Minute)),
+		"segundos":     obj.NewBuiltin(durationOf("segundos", time.Second)),
+		"milisegundos": obj.NewBuiltin(durationOf("milisegundos", time.Millisecond)),
+		"cronometro":   obj.NewBuiltin(newStopwatch),
+	})
+}
+
+// generates a date object with its fields and methods
+func NewDate(value time.Time) *obj.Date {
+	return obj.NewDate(value, map[string]obj.Object{
+		"anio":       &obj.Number{Value: value.Year()},
+		"mes":        &obj.Number{Value: int(value.Month())},
+		"dia":        &obj.Number{Value: value.Day()},
+		"hora":       &obj.Number{Value: value.Hour()},
+		"minuto":     &obj.Number{Value: value.Minute()},
+		"segundo":    &obj.Number{Value: value.Second()},
+		"nombre_mes": &obj.String{Value: monthNames[value.Month()-1]},
+		"dia_semana": &obj.String{Value: dayNames[value.Weekday()]},
+		"zona":       &obj.String{Value: value.Location().String()},
+		"unix":       &obj.Number{Value: int(value.Unix())},
+		"formatear":  obj.NewBuiltin(formatDateMethod(value)),
+		"en_zona":    obj.NewBuiltin(inZoneMethod(value)),
+	})
+}
+
+// generates a duration object with its fields
+func NewDuration(value time.Duration) *obj.Duration {
+	return obj.NewDuration(value, map[string]obj.Object{
+		"milisegundos": &obj.Number{Value: int(value.Milliseconds())},
+		"segundos":     obj.NewFloat(value.Seconds()),
+		"minutos":      obj.NewFloat(value.Minutes()),
+		"horas":        obj.NewFloat(value.Hours()),
+	})
+}
+
+// return the current date in the local time zone
+func now(args ...obj.Object) obj.Object {
+	if len(args) != 0 {
+		return wrongNumberofArgs("ahora", len(args), 0)
+	}
+
+	return NewDate(time.Now())
+}
+
+// generates a date from its parts like:
+//		tiempo.fecha(anio, mes, dia, hora, minuto, segundo, zona)
+// the time and the time zone are optional, by default the zone is the local one
+func newDateFromArgs(args ...obj.Object) obj.Object {
+	location := time.Local
+	if len(args) != 0 {
+		if zone, isStr := args[len(args)-1].(*obj.String); isStr {
+			loc, err := loadLocation(zone.Value)
+			if err != nil {
+				return err
+			}
+
+			location = loc
+			args = args[:len(args)-1]
+		}
+	}
+
+	if len(args) < 3 || len(args) > 6 {
+		return wrongNumberofArgs("fecha", len(args), 6)
+	}
+
+	parts := [6]int{}
+	for idx, arg := range args {
+		part, err := intArgument("fecha", arg)
+		if err != nil {
+			return err
+		}
+
+		parts[idx] = part
+	}
+
+	return dateFromParts(parts, location)
+}
+
+// parse the text with the given format, the time zone is optional
+func parseDate(args ...obj.Object) obj.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return wrongNumberofArgs("analizar", len(args), 3)
+	}
+
+	strArgs := make([]string, 0, len(args))
+	for _, arg := range args {
+		str, isStr := arg.(*obj.String)
+		if !isStr {
+			return unsoportedArgumentType("analizar", obj.Types[arg.Type()])
+		}
+
+		strArgs = append(strArgs, str.Value)
+	}
+
+	location := time.Local
+	if len(strArgs) == 3 {
+		loc, err := loadLocation(strArgs[2])
+		if err != nil {
+			return err
+		}
+
+		location = loc
+	}
+
+	return (&dateParser{text: strArgs[0], format: strArgs[1]}).parse(location)
+}
+
+// return a builtin that generates durations of the given unit
+func durationOf(funcName string, unit time.Duration) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		if len(args) != 1 {
+			return wrongNumberofArgs(funcName, len(args), 1)
+		}
+
+		amount, isNumber := toFloat(args[0])
+		if !isNumber {
+			return unsoportedArgumentType(funcName, obj.Types[args[0].Type()])
+		}
+
+		nanoseconds := amount * float64(unit)
+		if math.IsNaN(nanoseconds) || math.Abs(nanoseconds) >= math.MaxInt64 {
+			return &obj.Error{Message: fmt.Sprintf("la duracion de %s esta fuera de rango", funcName)}
+		}
+
+		return NewDuration(time.Duration(nanoseconds))
+	}
+}
+
+// generates a stopwatch that measures the time since it was created
+// or restarted using the monotonic clock
+func newStopwatch(args ...obj.Object) obj.Object {
+	if len(args) != 0 {
+		return wrongNumberofArgs("cronometro", len(args), 0)
+	}
+
+	start := time.Now()
+	return obj.NewStopwatch(map[string]obj.Object{
+		"transcurrido": obj.NewBuiltin(func(args ...obj.Object) obj.Object {
+			if len(args) != 0 {
+				return wrongNumberofArgs("transcurrido", len(args), 0)
+			}
+
+			return NewDuration(time.Since(start))
+		}),
+		"reiniciar": obj.NewBuiltin(func(args ...obj.Object) obj.Object {
+			if len(args) != 0 {
+				return wrongNumberofArgs("reiniciar", len(args), 0)
+			}
+
+			start = time.Now()
+			return obj.SingletonNUll
+		}),
+	})
+}
+
+// return the method that format the date with a pattern like:
+//		fecha.formatear("%A %d de %B de %Y")
+func formatDateMethod(value time.Time) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		format, err := textArg("formatear", args, 1)
+		if err != nil {
+			return err
+		}
+
+		formatted, err := formatDate(value, format)
+		if err != nil {
+			return err
+		}
+
+		return &obj.String{Value: formatted}
+	}
+}
+
+// return the method that convert the date to the given time zone
+func inZoneMethod(value time.Time) obj.BuiltinFunction {
+	return func(args ...obj.Object) obj.Object {
+		zone, err := textArg("en_zona", args, 1)
+		if err != nil {
+			return err
+		}
+
+		location, err := loadLocation(zone)
+		if err != nil {
+			return err
+		}
+
+		return NewDate(value.In(location))
+	}
+}
+
+// return the time zone with the given name like UTC or America/Mexico_City
+func loadLocation(name string) (*time.Location, *obj.Error) {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, &obj.Error{Message: fmt.Sprintf("la zona horaria %s no existe", name)}
+	}
+
+	return location, nil
+}
+
+// generates a date checking that all the parts are in range, the parts
+// are year, month, day, hour, minute and second
+func dateFromParts(parts [6]int, location *time.Location) obj.Object {
+	date := time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], parts[4], parts[5], 0, location)
+	if int(date.Month()) != parts[1] || date.Day() != parts[2] || date.Hour() != parts[3] ||
+		date.Minute() != parts[4] || date.Second() != parts[5] {
+		return &obj.Error{
+			Message: fmt.Sprintf(
+				"la fecha %04d-%02d-%02d %02d:%02d:%02d no es valida",
+				parts[0], parts[1], parts[2], parts[3], parts[4], parts[5],
+			),
+		}
+	}
+
+	return NewDate(date)
+}
+
+// format the date with the directives in the format:
+//		%Y anio, %y anio con dos digitos, %m mes, %d dia, %H hora, %M minuto,
+//		%S segundo, %B nombre del mes, %b nombre corto del mes, %A nombre del dia,
+//		%a nombre corto del dia, %Z nombre de la zona, %z diferencia con UTC
+func formatDate(value time.Time, format string) (string, *obj.Error) {
+	var buf strings.Builder
+	runes := []rune(format)
+	for idx := 0; idx < len(runes); idx++ {
+		if runes[idx] != '%' {
+			buf.WriteRune(runes[idx])
+			continue
+		}
+
+		idx++
+		if idx == len(runes) {
+			return "", invalidDirective("")
+		}
+
+		switch runes[idx] {
+		case 'Y':
+			fmt.Fprintf(&buf, "%04d", value.Year())
+		case 'y':
+			fmt.Fprintf(&buf, "%02d", value.Year()%100)
+		case 'm':
+			fmt.Fprintf(&buf, "%02d", int(value.Month()))
+		case 'd':
+			fmt.Fprintf(&buf, "%02d", value.Day())
+		case 'H':
+			fmt.Fprintf(&buf, "%02d", value.Hour())
+		case 'M':
+			fmt.Fprintf(&buf, "%02d", value.Minute())
+		case 'S':
+			fmt.Fprintf(&buf, "%02d", value.Second())
+		case 'B':
+			buf.WriteString(monthNames[value.Month()-1])
+		case 'b':
+			buf.WriteString(shortName(monthNames[value.Month()-1]))
+		case 'A':
+			buf.WriteString(dayNames[value.Weekday()])
+		case 'a':
+			buf.WriteString(shortName(dayNames[value.Weekday()]))
+		case 'Z':
+			buf.WriteString(value.Format("MST"))
+		case 'z':
+			buf.WriteString(value.Format("-0700"))
+		case '%':
+			buf.WriteRune('%')
+		default:
+			return "", invalidDirective(string(runes[idx]))
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// return the first three characters of the name
+func shortName(name string) string {
+	return string([]rune(name)[:3])
+}
+
+// return an error for an unknown format directive
+func invalidDirective(directive string) *obj.Error {
+	return &obj.Error{Message: fmt.Sprintf("la directiva de formato %%%s no es valida", directive)}
+}
+
+// represents the state while a date is parsed from a text
+type dateParser struct {
+	text   string // represents the text with the date
+	format string // represents the format with the same directives used to format
+	pos    int    // represents the position in the text
+}
+
+// parse the text with the format and return the date
+func (d *dateParser) parse(location *time.Location) obj.Object {
+	parts := [6]int{0, 1, 1, 0, 0, 0}
+	offset, hasOffset := 0, false
+
+	format := []rune(d.format)
+	for idx := 0; idx < len(format); idx++ {
+		if format[idx] != '%' || idx+1 == len(format) {
+			if !d.consume(string(format[idx])) {
+				return d.mismatch()
+			}
+			continue
+		}
+
+		idx++
+		var ok bool
+		switch format[idx] {
+		case 'Y':
+			parts[0], ok = d.number(4)
+		case 'y':
+			parts[0], ok = d.number(2)
+			parts[0] += 2000
+		case 'm':
+			parts[1], ok = d.number(2)
+		case 'd':
+			parts[2], ok = d.number(2)
+		case 'H':
+			parts[3], ok = d.number(2)
+		case 'M':
+			parts[4], ok = d.number(2)
+		case 'S':
+			parts[5], ok = d.number(2)
+		case 'B', 'b':
+			var month int
+			month, ok = d.name(monthNames[:], format[idx] == 'b')
+			parts[1] = month + 1
+		case 'A', 'a':
+			_, ok = d.name(dayNames[:], format[idx] == 'a')
+		case 'Z':
+			ok = d.zoneName()
+		case 'z':
+			offset, ok = d.offset()
+			hasOffset = true
+		case '%':
+			ok = d.consume("%")
+		default:
+			return invalidDirective(string(format[idx]))
+		}
+
+		if !ok {
+			return d.mismatch()
+		}
+	}
+
+	if d.pos != len(d.text) {
+		return d.mismatch()
+	}
+
+	if hasOffset {
+		location = time.FixedZone("", offset)
+	}
+
+	return dateFromParts(parts, location)
+}
+
+// consume the literal in the text if is the next part
+func (d *dateParser) consume(literal string) bool {
+	if !strings.HasPrefix(d.text[d.pos:], literal) {
+		return false
+	}
+
+	d.pos += len(literal)
+	return true
+}
+
+// read a number with at most the given digits
+func (d *dateParser) number(maxDigits int) (int, bool) {
+	start := d.pos
+	for d.pos < len(d.text) && d.pos-start < maxDigits && d.text[d.pos] >= '0' && d.text[d.pos] <= '9' {
+		d.pos++
+	}
+
+	num, err := strconv.Atoi(d.text[start:d.pos])
+	return num, err == nil
+}
+
+// read one of the names and return its index, the comparison ignore the case
+func (d *dateParser) name(names []string, short bool) (int, bool) {
+	rest := strings.ToLower(d.text[d.pos:])
+	for idx, name := range names {
+		if short {
+			name = shortName(name)
+		}
+
+		if strings.HasPrefix(rest, name) {
+			d.pos += len(name)
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+// read the letters of a time zone abbreviation, the abbreviation
+// is ignored because is ambiguous
+func (d *dateParser) zoneName() bool {
+	start := d.pos
+	for d.pos < len(d.text) && unicode.IsLetter(rune(d.text[d.pos])) {
+		d.pos++
+	}
+
+	return d.pos > start
+}
+
+// read an offset like -0600 and return it in seconds
+func (d *dateParser) offset() (int, bool) {
+	if d.pos == len(d.text) || (d.text[d.pos] != '+' && d.text[d.pos] != '-') {
+		return 0, false
+	}
+
+	sign := 1
+	if d.text[d.pos] == '-' {
+		sign = -1
+	}
+	d.pos++
+
+	start := d.pos
+	value, ok := d.number(4)
+	if !ok || d.pos-start != 4 {
+		return 0, false
+	}
+
+	return sign * (value/100*3600 + value%100*60), true
+}
+
+// return an error indicating the text does not match the format
+func (d *dateParser) mismatch() *obj.Error {
+	return &obj.Error{
+		Message: fmt.Sprintf("el texto %s no coincide con el formato %s", d.text, d.format),
+	}
+}
@@ -3,7 +3,8 @@ package builtins
 import (
 	obj "aura/src/object"
 	"fmt"
-	"strings"
+	"math"
+	"math/big"
 )
 
 func makeOneArgList(arg obj.Object) obj.Object {
@@ -81,10 +82,87 @@ func makeTreArgList(start, end, pass obj.Object) obj.Object {
 	return list
 }
 
-func formatString(str string, args []obj.Object) string {
-	for i := 0; i < len(args); i++ {
-		str = strings.Replace(str, "{}", args[i].Inspect(), 1)
+// return the value of an integer or float object as a float64
+func toFloat(arg obj.Object) (float64, bool) {
+	switch node := arg.(type) {
+	case *obj.Number:
+		return float64(node.Value), true
+
+	case *obj.BigInteger:
+		value, _ := new(big.Float).SetInt(node.Value).Float64()
+		return value, true
+
+	case *obj.Float:
+		return node.Value, true
+
+	default:
+		return 0, false
+	}
+}
+
+// return the value of an integer argument that must fit in an int like a
+// count or an index, the big integers are out of range
+func intArgument(funcName string, arg obj.Object) (int, *obj.Error) {
+	switch node := arg.(type) {
+	case *obj.Number:
+		return node.Value, nil
+
+	case *obj.BigInteger:
+		return 0, &obj.Error{
+			Message: fmt.Sprintf("el entero %s esta fuera de rango para %s", node.Inspect(), funcName),
+		}
+
+	default:
+		return 0, unsoportedArgumentType(funcName, obj.Types[arg.Type()])
+	}
+}
+
+// convert a float without decimals to an integer, the floats outside of
+// the int range are promoted to big integers
+func floatToInteger(funcName string, value float64) obj.Object {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return &obj.Error{
+			Message: fmt.Sprintf("%s no puede convertir %v en un entero", funcName, value),
+		}
+	}
+
+	// the float of the max int is 2**63, which is already out of range
+	if value >= math.MaxInt64 || value < math.MinInt64 {
+		integer, _ := big.NewFloat(value).Int(nil)
+		return obj.NewInteger(integer)
+	}
+
+	return &obj.Number{Value: int(value)}
+}
+
+// add all the given values. the result will be an integer unless
+// there is a float in the values
+func sumValues(funcName string, values []obj.Object) obj.Object {
+	intResult := new(big.Int)
+	var floatResult float64
+	isFloat := false
+
+	for _, value := range values {
+		switch item := value.(type) {
+		case *obj.Number:
+			intResult.Add(intResult, big.NewInt(int64(item.Value)))
+
+		case *obj.BigInteger:
+			intResult.Add(intResult, item.Value)
+
+		case *obj.Float:
+			floatResult += item.Value
+			isFloat = true
+
+		default:
+			return unsoportedArgumentType(funcName, obj.Types[value.Type()])
+		}
+	}
+
+	if isFloat {
+		intValue, _ := new(big.Float).SetInt(intResult).Float64()
+		return obj.NewFloat(floatResult + intValue)
 	}
 
-	return str
+	return obj.NewInteger(intResult)
 }
@@ -2,13 +2,17 @@ package evaluator
 
 import (
 	"aura/src/ast"
+	b "aura/src/builtins"
 	obj "aura/src/object"
+	"fmt"
+	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 // evaluate a map object
 func evaluateMap(mapa *ast.MapExpression, env *obj.Enviroment) obj.Object {
-	mapObj := &obj.Map{Store: map[string]obj.Object{}}
+	mapObj := obj.NewMap()
 
 	// we loop and evaluate all the key value pairs in the expression
 	// and add them to the hashMap
@@ -25,6 +29,21 @@ func evaluateMap(mapa *ast.MapExpression, env *obj.Enviroment) obj.Object {
 	return mapObj
 }
 
+// evaluate a set expression, the repeated values are added only once
+func evaluateSet(setExp *ast.SetExpression, env *obj.Enviroment) obj.Object {
+	set := obj.NewSet()
+	for _, value := range setExp.Values {
+		evaluated := Evaluate(value, env)
+		if _, isErr := evaluated.(*obj.Error); isErr {
+			return evaluated
+		}
+
+		set.Add(evaluated)
+	}
+
+	return set
+}
+
 // evaluate an array expression
 func evaluateArray(arr *ast.Array, env *obj.Enviroment) obj.Object {
 	list := new(obj.List)
@@ -39,14 +58,11 @@ func evaluateArray(arr *ast.Array, env *obj.Enviroment) obj.Object {
 // evaluate a list reassigment by index like:
 //		arr[0] = 2;
 func evaluateListReassigment(call *ast.CallList, list *obj.List, newVal ast.Expression, env *obj.Enviroment) obj.Object {
-	evaluated := Evaluate(call.Index, env)
-	num, isNum := evaluated.(*obj.Number)
-	if !isNum {
-		// the index is not a number
-		return newError("El indice debe ser un numero")
+	if slice, isSlice := call.Index.(*ast.Slice); isSlice {
+		return evaluateSliceReassigment(slice, list, newVal, env)
 	}
 
-	index, err := checkIndex(len(list.Values), num.Value)
+	index, err := checkIndexObject(Evaluate(call.Index, env), len(list.Values))
 	if err != nil {
 		return err
 	}
@@ -55,6 +71,54 @@ func evaluateListReassigment(call *ast.CallList, list *obj.List, newVal ast.Expr
 	return obj.SingletonNUll
 }
 
+// evaluate a reassigment of a part of the list like:
+//		arr[1:3] = lista[5, 6, 7];
+// with a step the new list must have the same length of the slice
+func evaluateSliceReassigment(slice *ast.Slice, list *obj.List, newVal ast.Expression, env *obj.Enviroment) obj.Object {
+	bounds, err := evaluateSliceBounds(slice, len(list.Values), env)
+	if err != nil {
+		return err
+	}
+
+	evaluated := Evaluate(newVal, env)
+	if err, isErr := evaluated.(*obj.Error); isErr {
+		return err
+	}
+
+	values, isList := evaluated.(*obj.List)
+	if !isList {
+		return newError("Solo se puede asignar una lista a una rebanada")
+	}
+
+	// copy the values in case the list is assigned to a slice of itself
+	newValues := append([]obj.Object{}, values.Values...)
+	if bounds.step == 1 {
+		end := bounds.end
+		if end < bounds.start {
+			end = bounds.start
+		}
+
+		tail := append(newValues, list.Values[end:]...)
+		list.Values = append(list.Values[:bounds.start], tail...)
+		return obj.SingletonNUll
+	}
+
+	indexes := bounds.indexes()
+	if len(indexes) != len(newValues) {
+		return newError(fmt.Sprintf(
+			"se esperaban %d valores para la rebanada, se recibieron %d",
+			len(indexes),
+			len(newValues),
+		))
+	}
+
+	for idx, index := range indexes {
+		list.Values[index] = newValues[idx]
+	}
+
+	return obj.SingletonNUll
+}
+
 // evaluate a HashMap reassigment
 func evaluateMapReassigment(hashMap *obj.Map, key obj.Object, value obj.Object) obj.Object {
 	// we dont care if the key doesnt exist
@@ -79,12 +143,19 @@ func evaluateReassigment(reassigment *ast.Reassignment, env *obj.Enviroment) obj
 
 	case *ast.CallList:
 		evaluated := Evaluate(exp.ListIdent, env)
+		if _, isTuple := evaluated.(*obj.Tuple); isTuple || isFrozen(evaluated) {
+			return cannotBeModified(obj.Types[evaluated.Type()])
+		}
 
 		if list, isList := evaluated.(*obj.List); isList {
 			return evaluateListReassigment(exp, list, reassigment.NewVal, env)
 		}
 
 		if hashMap, isMap := evaluated.(*obj.Map); isMap {
+			if _, isSlice := exp.Index.(*ast.Slice); isSlice {
+				return cannotBeSliced(obj.Types[hashMap.Type()])
+			}
+
 			key := Evaluate(exp.Index, env)
 			newVal := Evaluate(reassigment.NewVal, env)
 			return evaluateMapReassigment(hashMap, key, newVal)
@@ -97,6 +168,59 @@ func evaluateReassigment(reassigment *ast.Reassignment, env *obj.Enviroment) obj
 	}
 }
 
+// evaluate a set method if the method is valid will be applied else will return an error
+func evaluateSetMethods(set *obj.Set, method *obj.Method) obj.Object {
+	switch method.MethodType {
+	case obj.APPEND:
+		set.Add(method.Value)
+		return obj.SingletonNUll
+
+	case obj.DELETE:
+		if !set.Remove(method.Value) {
+			return newError(fmt.Sprintf("el valor %s no existe en el conjunto", method.Value.Inspect()))
+		}
+
+		return obj.SingletonNUll
+
+	case obj.CONTAIS:
+		return toBooleanObject(set.Contains(method.Value))
+
+	case obj.SUBSET:
+		return toBooleanObject(set.IsSubset(method.Value.(*obj.Set)))
+
+	case obj.SUPERSET:
+		return toBooleanObject(method.Value.(*obj.Set).IsSubset(set))
+
+	case obj.VALUES:
+		return &obj.List{Values: set.Values()}
+
+	case obj.COPY:
+		return set.Copy()
+
+	case obj.CLEAR:
+		set.Clear()
+		return obj.SingletonNUll
+
+	default:
+		return noSuchMethod(method.Inspect(), "conjunto")
+	}
+}
+
+// evaluate a tuple method, only the methods that do not modify the tuple are valid
+func evaluateTupleMethods(tuple *obj.Tuple, method *obj.Method) obj.Object {
+	list := &obj.List{Values: tuple.Values}
+	switch method.MethodType {
+	case obj.CONTAIS:
+		return list.Contains(method.Value)
+
+	case obj.INDEX:
+		return list.Index(method.Value)
+
+	default:
+		return noSuchMethod(method.Inspect(), "tupla")
+	}
+}
+
 // evaluate a list method if the method is valid will be applied else will return an error
 func evaluateListMethods(list *obj.List, method *obj.Method) obj.Object {
 	switch method.MethodType {
@@ -124,39 +248,350 @@ func evaluateListMethods(list *obj.List, method *obj.Method) obj.Object {
 
 	case obj.FILTER:
 		fn := method.Value.(*obj.Def)
+		if len(fn.Parameters) != 1 {
+			return newError("La funcion filtrar solo puede recibir un argumento para una lista")
+		}
+
 		return list.Filter(fn, applyFunction, isTruthy)
 
 	case obj.COUNT:
 		fn := method.Value.(*obj.Def)
 		return list.Count(fn, applyFunction, isTruthy)
 
+	case obj.SORT:
+		args := method.Value.(*obj.List).Values
+		return sortList(list, args[0], args[1].(*obj.Bool).Value)
+
+	case obj.REVERSE:
+		return list.Reverse()
+
+	case obj.INSERT:
+		args := method.Value.(*obj.List).Values
+		return list.Insert(args[0].(*obj.Number).Value, args[1])
+
+	case obj.EXTEND:
+		list.Extend(method.Value.(*obj.List))
+		return obj.SingletonNUll
+
+	case obj.INDEX:
+		return list.Index(method.Value)
+
+	case obj.UNIQUE:
+		return list.Unique()
+
+	case obj.REDUCE:
+		return reduceList(list, method.Value.(*obj.List).Values)
+
+	case obj.ZIP:
+		others := []*obj.List{}
+		for _, other := range method.Value.(*obj.List).Values {
+			others = append(others, other.(*obj.List))
+		}
+
+		return list.Zip(others)
+
+	case obj.ENUMERATE:
+		start := 0
+		if num, isNum := method.Value.(*obj.Number); isNum {
+			start = num.Value
+		}
+
+		return list.Enumerate(start)
+
+	case obj.ANY, obj.ALL:
+		return anyOrAll(list, method.Value.(*obj.Def), method.MethodType == obj.ANY)
+
+	case obj.MIN, obj.MAX:
+		return minOrMax(list, method.Value, method.MethodType == obj.MAX)
+
+	case obj.COPY:
+		return list.Copy()
+
+	case obj.FLATTEN:
+		depth := -1
+		if num, isNum := method.Value.(*obj.Number); isNum {
+			depth = num.Value
+		}
+
+		return list.Flatten(depth)
+
+	case obj.GROUPBY:
+		return groupBy(list, method.Value.(*obj.Def))
+
 	default:
 		return noSuchMethod(method.Inspect(), "list")
 	}
 }
 
+// sort the list in place with a stable sort, if there is a key function
+// the values returned by the function are compared instead of the values
+func sortList(list *obj.List, key obj.Object, reverse bool) obj.Object {
+	keys, err := sortKeys(list, key)
+	if err != nil {
+		return err
+	}
+
+	indexes := make([]int, len(list.Values))
+	for idx := range indexes {
+		indexes[idx] = idx
+	}
+
+	var compareErr *obj.Error
+	sort.SliceStable(indexes, func(i, j int) bool {
+		comparison, err := compareObjects(keys[indexes[i]], keys[indexes[j]])
+		if err != nil && compareErr == nil {
+			compareErr = err
+		}
+
+		if reverse {
+			return comparison > 0
+		}
+
+		return comparison < 0
+	})
+
+	if compareErr != nil {
+		return compareErr
+	}
+
+	sorted := make([]obj.Object, len(list.Values))
+	for idx, original := range indexes {
+		sorted[idx] = list.Values[original]
+	}
+
+	list.Values = sorted
+	return obj.SingletonNUll
+}
+
+// return the values used to compare the elements of the list
+func sortKeys(list *obj.List, key obj.Object) ([]obj.Object, *obj.Error) {
+	fn, hasKey := key.(*obj.Def)
+	if !hasKey {
+		return list.Values, nil
+	}
+
+	keys := make([]obj.Object, 0, len(list.Values))
+	for _, val := range list.Values {
+		evaluated := applyFunction(fn, val)
+		if err, isErr := evaluated.(*obj.Error); isErr {
+			return nil, err
+		}
+
+		keys = append(keys, evaluated)
+	}
+
+	return keys, nil
+}
+
+// compare two values, return a negative number if the left value is lower,
+// a positive number if is greater and 0 if are equal
+func compareObjects(left, rigth obj.Object) (int, *obj.Error) {
+	for _, operator := range []string{"<", ">"} {
+		result := evaluateInfixExpression(operator, left, rigth, nil, nil)
+		if _, isErr := result.(*obj.Error); isErr {
+			return 0, newError(fmt.Sprintf(
+				"no se pueden comparar valores de tipo %s y %s",
+				obj.Types[left.Type()],
+				obj.Types[rigth.Type()],
+			))
+		}
+
+		if result == obj.SingletonTRUE {
+			if operator == "<" {
+				return -1, nil
+			}
+
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// reduce the list to a single value calling the function with the
+// accumulated value and each element
+func reduceList(list *obj.List, args []obj.Object) obj.Object {
+	fn := args[0].(*obj.Def)
+	values := list.Values
+	var accumulated obj.Object
+	if len(args) == 2 {
+		accumulated = args[1]
+	} else {
+		if len(values) == 0 {
+			return newError("no se puede reducir una lista vacia sin un valor inicial")
+		}
+
+		accumulated, values = values[0], values[1:]
+	}
+
+	for _, val := range values {
+		accumulated = applyFunction(fn, accumulated, val)
+		if _, isErr := accumulated.(*obj.Error); isErr {
+			return accumulated
+		}
+	}
+
+	return accumulated
+}
+
+// check if any or all the values of the list satisfy the function
+func anyOrAll(list *obj.List, fn *obj.Def, any bool) obj.Object {
+	for _, val := range list.Values {
+		evaluated := applyFunction(fn, val)
+		if _, isErr := evaluated.(*obj.Error); isErr {
+			return evaluated
+		}
+
+		if isTruthy(evaluated) == any {
+			return toBooleanObject(any)
+		}
+	}
+
+	return toBooleanObject(!any)
+}
+
+// return the min or max value of the list, if there is a key function
+// the values returned by the function are compared
+func minOrMax(list *obj.List, key obj.Object, max bool) obj.Object {
+	if len(list.Values) == 0 {
+		return newError("no se puede obtener el minimo o maximo de una lista vacia")
+	}
+
+	keys, err := sortKeys(list, key)
+	if err != nil {
+		return err
+	}
+
+	best := 0
+	for idx := 1; idx < len(keys); idx++ {
+		comparison, err := compareObjects(keys[idx], keys[best])
+		if err != nil {
+			return err
+		}
+
+		if (max && comparison > 0) || (!max && comparison < 0) {
+			best = idx
+		}
+	}
+
+	return list.Values[best]
+}
+
+// group the values of the list in a map where the keys are the values
+// returned by the function
+func groupBy(list *obj.List, fn *obj.Def) obj.Object {
+	groups := obj.NewMap()
+	for _, val := range list.Values {
+		key := applyFunction(fn, val)
+		if _, isErr := key.(*obj.Error); isErr {
+			return key
+		}
+
+		group, exists := groups.Get(key).(*obj.List)
+		if !exists {
+			group = new(obj.List)
+			groups.UpdateKey(key, group)
+		}
+
+		group.Add(val)
+	}
+
+	return groups
+}
+
 // evaluate a map method if the method is valid will be applied else will return an error
 func evaluateMapMethods(hashMap *obj.Map, method *obj.Method) obj.Object {
 	switch method.MethodType {
 	case obj.CONTAIS:
-		if hashMap.Get(method.Value.Inspect()) != obj.NullVAlue {
+		if hashMap.Get(method.Value) != obj.NullVAlue {
 			return obj.SingletonTRUE
 		}
 
 		return obj.SingletonFALSE
 
 	case obj.VALUES:
-		list := new(obj.List)
-		for _, val := range hashMap.Store {
-			list.Values = append(list.Values, val)
+		return &obj.List{Values: hashMap.Values()}
+
+	case obj.KEYS:
+		return &obj.List{Values: append([]obj.Object{}, hashMap.Keys...)}
+
+	case obj.PAIRS:
+		return hashMap.Pairs()
+
+	case obj.DELETE:
+		value, exists := hashMap.Delete(method.Value)
+		if !exists {
+			return keyNotFound(method.Value.Inspect())
+		}
+
+		return value
+
+	case obj.GET:
+		args := method.Value.(*obj.List).Values
+		if hashMap.Has(args[0]) {
+			return hashMap.Get(args[0])
+		}
+
+		if len(args) == 2 {
+			return args[1]
+		}
+
+		return obj.NullVAlue
+
+	case obj.MERGE:
+		merged := hashMap.Copy()
+		merged.Update(method.Value.(*obj.Map))
+		return merged
+
+	case obj.UPDATE:
+		hashMap.Update(method.Value.(*obj.Map))
+		return obj.SingletonNUll
+
+	case obj.HAS:
+		return toBooleanObject(hashMap.Has(method.Value))
+
+	case obj.CLEAR:
+		hashMap.Clear()
+		return obj.SingletonNUll
+
+	case obj.COPY:
+		return hashMap.Copy()
+
+	case obj.TRANSFORM, obj.FILTER:
+		fn := method.Value.(*obj.Def)
+		if len(fn.Parameters) != 2 {
+			return newError("la funcion para un mapa debe recibir la llave y el valor")
 		}
-		return list
+
+		return transformMap(hashMap, fn, method.MethodType == obj.FILTER)
 
 	default:
 		return noSuchMethod(method.Inspect(), "mapa")
 	}
 }
 
+// return a new map calling the function with each key and value. if filter
+// is true the map contains the pairs where the function returns a truthy value,
+// else the map contains the values returned by the function
+func transformMap(hashMap *obj.Map, fn *obj.Def, filter bool) obj.Object {
+	result := obj.NewMap()
+	for _, key := range hashMap.Keys {
+		value := hashMap.Get(key)
+		evaluated := applyFunction(fn, key, value)
+		if _, isErr := evaluated.(*obj.Error); isErr {
+			return evaluated
+		}
+
+		if !filter {
+			result.UpdateKey(key, evaluated)
+		} else if isTruthy(evaluated) {
+			result.UpdateKey(key, value)
+		}
+	}
+
+	return result
+}
+
 // evaluate a string method if the method is valid will be applied else will return an error
 func evaluateStringMethod(str *obj.String, method *obj.Method) obj.Object {
 	switch method.MethodType {
@@ -186,19 +621,116 @@ func evaluateStringMethod(str *obj.String, method *obj.Method) obj.Object {
 		separator := method.Value.(*obj.String)
 		return str.Split(separator.Value)
 
+	case obj.TRIM:
+		if cutset, isStr := method.Value.(*obj.String); isStr {
+			return &obj.String{Value: strings.Trim(str.Value, cutset.Value)}
+		}
+
+		return &obj.String{Value: strings.TrimSpace(str.Value)}
+
+	case obj.REPLACE:
+		args := method.Value.(*obj.List).Values
+		times := -1
+		if len(args) == 3 {
+			times = args[2].(*obj.Number).Value
+		}
+
+		return &obj.String{Value: strings.Replace(str.Value, args[0].Inspect(), args[1].Inspect(), times)}
+
+	case obj.STARTSWITH:
+		return toBooleanObject(strings.HasPrefix(str.Value, method.Value.Inspect()))
+
+	case obj.ENDSWITH:
+		return toBooleanObject(strings.HasSuffix(str.Value, method.Value.Inspect()))
+
+	case obj.FIND:
+		return str.Find(method.Value.Inspect())
+
+	case obj.REPEAT:
+		return str.Repeat(method.Value.(*obj.Number).Value)
+
+	case obj.JOIN:
+		values := method.Value.(*obj.List).Values
+		parts := make([]string, 0, len(values))
+		for _, value := range values {
+			parts = append(parts, value.Inspect())
+		}
+
+		return &obj.String{Value: strings.Join(parts, str.Value)}
+
+	case obj.PADLEFT, obj.PADRIGHT:
+		args := method.Value.(*obj.List).Values
+		length := args[0].(*obj.Number).Value
+		return str.Pad(length, args[1].Inspect(), method.MethodType == obj.PADLEFT)
+
+	case obj.REVERSE:
+		return str.Reverse()
+
+	case obj.ISNUMBER:
+		return str.IsNumber()
+
+	case obj.ISLETTER:
+		return str.IsLetter()
+
+	case obj.CHARS:
+		return str.Chars()
+
+	case obj.FORMAT:
+		formatted, err := b.FormatString(str.Value, method.Value.(*obj.List).Values)
+		if err != nil {
+			return err
+		}
+
+		return &obj.String{Value: formatted}
+
+	case obj.SLICE:
+		args := method.Value.(*obj.List).Values
+		end := utf8.RuneCountInString(str.Value)
+		if len(args) == 2 {
+			end = args[1].(*obj.Number).Value
+		}
+
+		return str.Slice(args[0].(*obj.Number).Value, end)
+
 	default:
 		return noSuchMethod(method.Inspect(), "texto")
 	}
 }
 
+// check if the expression is a call to a builtin function
+func isBuiltinCall(expression ast.Expression, env *obj.Enviroment) bool {
+	call, isCall := expression.(*ast.Call)
+	if !isCall {
+		return false
+	}
+
+	ident, isIdent := call.Function.(*ast.Identifier)
+	if !isIdent {
+		return false
+	}
+
+	_, isBuiltin := Evaluate(ident, env).(*obj.Builtin)
+	return isBuiltin
+}
+
 // evaluate a method expression
 func evaluateMethod(methodExp *ast.MethodExpression, env *obj.Enviroment) obj.Object {
 	evaluated := Evaluate(methodExp.Obj, env)
-	method, isMethod := Evaluate(methodExp.Method, env).(*obj.Method)
+	evaluatedMethod := Evaluate(methodExp.Method, env)
+	method, isMethod := evaluatedMethod.(*obj.Method)
+	if err, isErr := evaluatedMethod.(*obj.Error); isErr && isBuiltinCall(methodExp.Method, env) {
+		// the method exists but the arguments are not valid
+		return err
+	}
+
 	if !isMethod {
 		return notAMethod(methodExp.Method.Str())
 	}
 
+	if isFrozen(evaluated) && mutatingMethods[method.MethodType] {
+		return cannotBeModified(obj.Types[evaluated.Type()])
+	}
+
 	// we check the type of the method object
 	switch data := evaluated.(type) {
 
@@ -211,6 +743,12 @@ func evaluateMethod(methodExp *ast.MethodExpression, env *obj.Enviroment) obj.Ob
 	case *obj.String:
 		return evaluateStringMethod(data, method)
 
+	case *obj.Set:
+		return evaluateSetMethods(data, method)
+
+	case *obj.Tuple:
+		return evaluateTupleMethods(data, method)
+
 	default:
 		// the object has no methods
 		return noSuchMethod(methodExp.Method.Str(), methodExp.Obj.Str())
@@ -78,8 +78,12 @@ func notAClass(ident string) *obj.Error {
 	return &obj.Error{Message: fmt.Sprintf("no es una clase %s", ident)}
 }
 
-func indexOutOfRange(found, actual int) *obj.Error {
-	msg := fmt.Sprintf("Indice fuera de rango indice: %d, longitud: %d", found, actual)
+func powerTooLarge() *obj.Error {
+	return newError("el resultado de la potencia es demasiado grande")
+}
+
+func indexOutOfRange(found interface{}, actual int) *obj.Error {
+	msg := fmt.Sprintf("Indice fuera de rango indice: %v, longitud: %d", found, actual)
 	return newError(msg)
 }
 
@@ -90,3 +94,27 @@ func noSuchField(class string, ident string) *obj.Error {
 func notAMethod(ident string) *obj.Error {
 	return &obj.Error{Message: fmt.Sprintf("%s no es un metodo", ident)}
 }
+
+func noSuchMember(namespace string, ident string) *obj.Error {
+	return newError(fmt.Sprintf("%s no tiene el miembro %s", namespace, ident))
+}
+
+func keyNotFound(key string) *obj.Error {
+	return newError(fmt.Sprintf("la llave %s no existe en el mapa", key))
+}
+
+func cannotBeSliced(ident string) *obj.Error {
+	return newError(fmt.Sprintf("El objecto %s no puede ser rebanado", ident))
+}
+
+func cannotUnpack(value string, count int) *obj.Error {
+	return newError(fmt.Sprintf("no se puede desempaquetar %s en %d variables", value, count))
+}
+
+func constantReassignment(ident string) *obj.Error {
+	return newError(fmt.Sprintf("no se puede reasignar la constante %s", ident))
+}
+
+func cannotBeModified(ident string) *obj.Error {
+	return newError(fmt.Sprintf("El objecto %s no puede ser modificado", ident))
+}
@@ -3,10 +3,17 @@ package evaluator
 import (
 	"aura/src/ast"
 	b "aura/src/builtins"
+	l "aura/src/lexer"
 	obj "aura/src/object"
+	"strings"
 	"unicode/utf8"
 )
 
+// we give the builtins a way to call aura functions
+func init() {
+	b.SetApplyFunction(applyFunction)
+}
+
 // evlauate given nodes of the ast
 func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 	switch node := baseNode.(type) {
@@ -25,6 +32,10 @@ func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 		CheckIsNotNil(node.Value)
 		return &obj.Number{Value: *node.Value}
 
+	case *ast.BigInteger:
+		CheckIsNotNil(node.Value)
+		return &obj.BigInteger{Value: node.Value}
+
 	case *ast.FloatExp:
 		CheckIsNotNil(node.Value)
 		return &obj.Float{Value: node.Value}
@@ -42,11 +53,22 @@ func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 	case *ast.Infix:
 		CheckIsNotNil(node.Left)
 		CheckIsNotNil(node.Rigth)
+		if isAssignmentOperator(node.Operator) {
+			if err := checkConstant(node.Left, env); err != nil {
+				return err
+			}
+		}
+
 		left := Evaluate(node.Left, env)
 		rigth := Evaluate(node.Rigth, env)
 		CheckIsNotNil(left)
 		CheckIsNotNil(rigth)
-		return evaluateInfixExpression(node.Operator, left, rigth, env, node.Left)
+		result := evaluateInfixExpression(node.Operator, left, rigth, env, node.Left)
+		if isAssignmentOperator(node.Operator) {
+			reassignIfReplaced(node.Left, left, result, env)
+		}
+
+		return result
 
 	case *ast.Block:
 		return evaluateBLockStaments(node, env)
@@ -84,8 +106,14 @@ func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 	case *ast.Suffix:
 		CheckIsNotNil(node.Left)
 		CheckIsNotNil(node.Operator)
+		if err := checkConstant(node.Left, env); err != nil {
+			return err
+		}
+
 		left := Evaluate(node.Left, env)
-		return evaluateSuffixExpression(node.Operator, left)
+		result := evaluateSuffixExpression(node.Operator, left)
+		reassignIfReplaced(node.Left, left, result, env)
+		return result
 
 	case *ast.Reassignment:
 		CheckIsNotNil(node.Identifier)
@@ -95,12 +123,20 @@ func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 	case *ast.NullExpression:
 		return obj.NullVAlue
 
+	case *ast.Comprehension:
+		CheckIsNotNil(node.Range)
+		return evaluateComprehension(node, env)
+
+	case *ast.SetExpression:
+		return evaluateSet(node, env)
+
 	case *ast.MapExpression:
 		CheckIsNotNil(node.Body)
 		return evaluateMap(node, env)
 
 	case *ast.ClassStatement:
 		class := obj.NewClass(node.Name, node.Params, node.Methods)
+		class.Doc = node.Doc
 		env.SetItem(class.Name.Value, class)
 		return obj.SingletonNUll
 
@@ -111,7 +147,9 @@ func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 
 	case *ast.ArrowFunc:
 		CheckIsNotNil(node.Body)
-		return obj.NewDef(node.Body, env, node.Params...)
+		def := obj.NewDef(node.Body, env, node.Params...)
+		def.Doc = node.Doc
+		return def
 
 	case *ast.ClassCall:
 		CheckIsNotNil(node.Arguments)
@@ -126,6 +164,15 @@ func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 		CheckIsNotNil(node.Value)
 		value := Evaluate(node.Value, env)
 		CheckIsNotNil(node.Name)
+		if err := checkLocalConstant(node.Name.Value, env); err != nil {
+			return err
+		}
+
+		if node.Token.Token_type == l.CONST {
+			env.SetConstant(node.Name.Value, value)
+			return obj.SingletonNUll
+		}
+
 		env.SetItem(node.Name.Value, value)
 		return obj.SingletonNUll
 
@@ -133,6 +180,10 @@ func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 		CheckIsNotNil(node.Val)
 		value := Evaluate(node.Val, env)
 		CheckIsNotNil(node.Name)
+		if err := checkLocalConstant(node.Name.Value, env); err != nil {
+			return err
+		}
+
 		env.SetItem(node.Name.Value, value)
 		return value
 
@@ -173,6 +224,9 @@ func Evaluate(baseNode ast.ASTNode, env *obj.Enviroment) obj.Object {
 	case *ast.StringLiteral:
 		return &obj.String{Value: node.Value}
 
+	case *ast.InterpolatedString:
+		return evaluateInterpolatedString(node, env)
+
 	default:
 		return obj.SingletonNUll
 	}
@@ -221,6 +275,10 @@ func evaluateVarReassigment(variable *ast.Identifier, newVal ast.Expression, env
 		return unknownIdentifier(variable.Value)
 	}
 
+	if env.IsConstant(variable.Value) {
+		return constantReassignment(variable.Value)
+	}
+
 	env.Store[variable.Value] = Evaluate(newVal, env)
 	return obj.SingletonNUll
 }
@@ -229,11 +287,16 @@ func evaluateVarReassigment(variable *ast.Identifier, newVal ast.Expression, env
 func evaluateFor(forLoop *ast.For, env *obj.Enviroment) obj.Object {
 	evaluated := Evaluate(forLoop.Condition, env)
 	if iter, isIter := evaluated.(*obj.Iterator); isIter {
+		defer iter.Close()
 
 		// this does not fail because if not a variable the error will be handle by
 		// the evaluate iter function
-		val := forLoop.Condition.(*ast.RangeExpression).Variable.(*ast.Identifier).Value
-		for iter.Next() != nil {
+		variable := forLoop.Condition.(*ast.RangeExpression).Variable
+		for value := iter.Next(); value != nil; value = iter.Next() {
+			if err := setRangeVariables(variable, value, iter.Env); err != nil {
+				return err
+			}
+
 			evaluated = Evaluate(forLoop.Body, iter.Env)
 			switch node := evaluated.(type) {
 			case *obj.Return:
@@ -241,16 +304,13 @@ func evaluateFor(forLoop *ast.For, env *obj.Enviroment) obj.Object {
 
 			case *obj.BreakObj:
 				return obj.SingletonNUll
-
-			case *obj.ContinueObj:
-				iter.Env.SetItem(val, iter.Current)
-				continue
-
-			default:
-				iter.Env.SetItem(val, iter.Current)
 			}
 		}
 
+		if err := iter.Err(); err != nil {
+			return err
+		}
+
 		return obj.SingletonNUll
 	}
 
@@ -264,27 +324,157 @@ func evaluateFor(forLoop *ast.For, env *obj.Enviroment) obj.Object {
 // evaluate an iter expression like:
 //		for(i in range(10)):
 func evaluateRange(rangeExpress *ast.RangeExpression, env *obj.Enviroment) obj.Object {
-	val, isVar := rangeExpress.Variable.(*ast.Identifier)
-	if !isVar {
+	_, isDestructuring := rangeExpress.Variable.(*ast.Destructuring)
+	if _, isVar := rangeExpress.Variable.(*ast.Identifier); !isVar && !isDestructuring {
 		return notAVariable(rangeExpress.Variable.Str())
 	}
 
-	if list, isList := Evaluate(rangeExpress.Range, env).(*obj.List); isList {
+	var iter *obj.Iterator
+	switch iterable := Evaluate(rangeExpress.Range, env).(type) {
+	case *obj.List:
 		// if the iter is a list we make a iterable with the list
-		iter := obj.NewIterator(list.Values[0], list.Values, obj.NewEnviroment(env))
-		iter.Env.SetItem(val.Value, iter.Current)
-		return iter
-	}
+		iter = newListIterator(iterable.Values, env)
+
+	case *obj.Map:
+		if isDestructuring {
+			// with several variables we iterate the key value pairs of the map
+			iter = newListIterator(iterable.Pairs().Values, env)
+			break
+		}
+
+		// if the iter is a map we make a iterable with the keys of the map
+		iter = newListIterator(append([]obj.Object{}, iterable.Keys...), env)
 
-	if str, isStr := Evaluate(rangeExpress.Range, env).(*obj.String); isStr {
+	case *obj.Tuple:
+		iter = newListIterator(iterable.Values, env)
+
+	case *obj.Set:
+		// if the iter is a set we make a iterable with the values of the set
+		iter = newListIterator(iterable.Values(), env)
+
+	case *obj.String:
 		// if the iter is a string we make a iterable with all the string characters
-		list := makeStringList(str.Value)
-		iter := obj.NewIterator(list[0], list, obj.NewEnviroment(env))
-		iter.Env.SetItem(val.Value, iter.Current)
-		return iter
+		iter = newListIterator(makeStringList(iterable.Value), env)
+
+	case *obj.Generator:
+		// if the iter is a generator the values are requested while iterating
+		iter = obj.NewLazyIterator(iterable, obj.NewEnviroment(env))
+
+	case *obj.Error:
+		return iterable
+
+	default:
+		return notIterable(rangeExpress.Range.Str())
+	}
+
+	return iter
+}
+
+// set the values of the loop variables in the enviroment, with several variables
+// each value must be a list with one value for each variable like:
+//		por(k, v en lista[lista["a", 1], lista["b", 2]])
+func setRangeVariables(variable ast.Expression, value obj.Object, env *obj.Enviroment) *obj.Error {
+	destructuring, isDestructuring := variable.(*ast.Destructuring)
+	if !isDestructuring {
+		env.SetItem(variable.(*ast.Identifier).Value, value)
+		return nil
+	}
+
+	list, isList := value.(*obj.List)
+	if !isList || len(list.Values) != len(destructuring.Variables) {
+		return cannotUnpack(value.Inspect(), len(destructuring.Variables))
+	}
+
+	for idx, ident := range destructuring.Variables {
+		env.SetItem(ident.Value, list.Values[idx])
+	}
+
+	return nil
+}
+
+// evaluate a list or map comprehension, the loop variables are set in the
+// enviroment of the iterator so they do not leak to the outer enviroment
+func evaluateComprehension(comprehension *ast.Comprehension, env *obj.Enviroment) obj.Object {
+	evaluated := evaluateRange(comprehension.Range, env)
+	iter, isIter := evaluated.(*obj.Iterator)
+	if !isIter {
+		return evaluated
+	}
+
+	defer iter.Close()
+	keyValue, isMap := comprehension.Value.(*ast.KeyValue)
+	list := &obj.List{Values: []obj.Object{}}
+	hashMap := obj.NewMap()
+	set := obj.NewSet()
+	for value := iter.Next(); value != nil; value = iter.Next() {
+		if err := setRangeVariables(comprehension.Range.Variable, value, iter.Env); err != nil {
+			return err
+		}
+
+		if comprehension.Condition != nil {
+			condition := Evaluate(comprehension.Condition, iter.Env)
+			if _, isErr := condition.(*obj.Error); isErr {
+				return condition
+			}
+
+			if !isTruthy(condition) {
+				continue
+			}
+		}
+
+		if !isMap {
+			evaluated = Evaluate(comprehension.Value, iter.Env)
+			if _, isErr := evaluated.(*obj.Error); isErr {
+				return evaluated
+			}
+
+			if comprehension.Token.Token_type == l.SET {
+				set.Add(evaluated)
+				continue
+			}
+
+			list.Values = append(list.Values, evaluated)
+			continue
+		}
+
+		key := Evaluate(keyValue.Key, iter.Env)
+		if _, isErr := key.(*obj.Error); isErr {
+			return key
+		}
+
+		evaluated = Evaluate(keyValue.Value, iter.Env)
+		if _, isErr := evaluated.(*obj.Error); isErr {
+			return evaluated
+		}
+
+		// like in a loop the last value of a repeated key is kept
+		hashMap.UpdateKey(key, evaluated)
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	switch {
+	case isMap:
+		return hashMap
+
+	case comprehension.Token.Token_type == l.SET:
+		return set
+
+	default:
+		return list
 	}
+}
 
-	return notIterable(rangeExpress.Range.Str())
+// generates a new iterator over the given values
+func newListIterator(values []obj.Object, env *obj.Enviroment) *obj.Iterator {
+	var current obj.Object = obj.NullVAlue
+	if len(values) != 0 {
+		current = values[0]
+	}
+
+	return obj.NewIterator(current, values, obj.NewEnviroment(env))
 }
 
 // extends the class enviroment with the methods and constructor arguments
@@ -295,7 +485,9 @@ func extendClassEnviroment(class *obj.Class, args []obj.Object, methods []*ast.C
 	}
 
 	for _, method := range methods {
-		classEnv.SetItem(method.Name.Value, obj.NewDef(method.Body, classEnv, method.Params...))
+		def := obj.NewDef(method.Body, classEnv, method.Params...)
+		def.Doc = method.Doc
+		classEnv.SetItem(method.Name.Value, def)
 	}
 
 	return classEnv
@@ -329,9 +521,58 @@ func evaluateClassFieldCall(call *ast.ClassFieldCall, env *obj.Enviroment) obj.O
 		return Evaluate(call.Field, class.Env)
 	}
 
+	if namespace, isNamespace := evaluated.(obj.Namespace); isNamespace {
+		return evaluateNamespaceFieldCall(call.Field, namespace, env)
+	}
+
 	return notAClass(evaluated.Inspect())
 }
 
+// evaluate a call to a member of a module or a standard library object like:
+//		matematicas.raiz(x)
+// the arguments are evaluated in the caller enviroment
+func evaluateNamespaceFieldCall(field ast.Expression, namespace obj.Namespace, env *obj.Enviroment) obj.Object {
+	switch field := field.(type) {
+	case *ast.Identifier:
+		return getMember(namespace, field.Value)
+
+	case *ast.Call:
+		ident, isIdent := field.Function.(*ast.Identifier)
+		if !isIdent {
+			return noSuchMember(namespace.Inspect(), field.Function.Str())
+		}
+
+		function := getMember(namespace, ident.Value)
+		if _, isErr := function.(*obj.Error); isErr {
+			return function
+		}
+
+		args := evaluateExpression(field.Arguments, env)
+		return applyFunction(function, args...)
+
+	case *ast.CallList:
+		container := evaluateNamespaceFieldCall(field.ListIdent, namespace, env)
+		if _, isErr := container.(*obj.Error); isErr {
+			return container
+		}
+
+		return evaluateIndex(container, field, env)
+
+	default:
+		return noSuchMember(namespace.Inspect(), field.Str())
+	}
+}
+
+// return the member with the given name if exists
+func getMember(namespace obj.Namespace, name string) obj.Object {
+	member, exists := namespace.Member(name)
+	if !exists {
+		return noSuchMember(namespace.Inspect(), name)
+	}
+
+	return member
+}
+
 // evaluate a class field reassigment
 func evaluateFieldReassigment(call *ast.ClassFieldCall, class *obj.ClassInstance, newVal ast.Expression) obj.Object {
 	ident, isIdent := call.Field.(*ast.Identifier)
@@ -369,6 +610,11 @@ func evaluateTernaryIf(ternary *ast.TernaryIf, env *obj.Enviroment) obj.Object {
 func evaluateImportStatement(importStmt *ast.ImportStatement, env *obj.Enviroment) obj.Object {
 	evaluated := Evaluate(importStmt.Path, env)
 	if str, isStr := evaluated.(*obj.String); isStr {
+		if module, isModule := b.LoadModule(str.Value); isModule {
+			env.SetItem(module.Name, module)
+			return obj.SingletonNUll
+		}
+
 		fileEnv, err := importEnv(str.Value)
 		if err != nil {
 			return err
@@ -448,15 +694,16 @@ func evaluateProgram(program *ast.Program, env *obj.Enviroment) obj.Object {
 
 // evaluate a function a expression
 func evaluateFunction(function *ast.Function, env *obj.Enviroment) obj.Object {
+	def := obj.NewDef(function.Body, env, function.Parameters...)
+	def.Doc = function.Doc
 	if function.Name != nil {
 		// if the name is not nil we have a named function like func main() {}
-		def := obj.NewDef(function.Body, env, function.Parameters...)
 		env.SetItem(function.Name.Value, def)
 		return obj.SingletonNUll
 	}
 
 	// we have an anonimous function like x := func(a, b) {}
-	return obj.NewDef(function.Body, env, function.Parameters...)
+	return def
 }
 
 // evaluate a while looop expression
@@ -490,33 +737,59 @@ func evaluateWhileExpression(whileExpression *ast.While, env *obj.Enviroment) ob
 // Evaluate a call to a datastructure like:
 //		array[0];
 func evaluateCallList(call *ast.CallList, env *obj.Enviroment) obj.Object {
-	evaluated := Evaluate(call.ListIdent, env)
+	return evaluateIndex(Evaluate(call.ListIdent, env), call, env)
+}
+
+// evaluate the index of the call in the evaluated data structure
+func evaluateIndex(evaluated obj.Object, call *ast.CallList, env *obj.Enviroment) obj.Object {
 	switch object := evaluated.(type) {
 
 	case *obj.List:
 		return evaluateListCall(object, call, env)
 
 	case *obj.Map:
+		if _, isSlice := call.Index.(*ast.Slice); isSlice {
+			return cannotBeSliced(obj.Types[object.Type()])
+		}
+
 		evaluated := Evaluate(call.Index, env)
-		return object.Get(evaluated.Inspect())
+		return object.Get(evaluated)
 
 	case *obj.String:
 		return evaluateStringCall(object, call, env)
 
+	case *obj.Tuple:
+		// the tuple is indexed like a list but the slices are also tuples
+		evaluated := evaluateListCall(&obj.List{Values: object.Values}, call, env)
+		if _, isSlice := call.Index.(*ast.Slice); isSlice {
+			if sliced, isList := evaluated.(*obj.List); isList {
+				return obj.NewTuple(sliced.Values...)
+			}
+		}
+
+		return evaluated
+
 	default:
 		return cannotBeIndexed(obj.Types[evaluated.Type()])
 	}
 }
 
 func evaluateListCall(list *obj.List, call *ast.CallList, env *obj.Enviroment) obj.Object {
-	evaluated := Evaluate(call.Index, env)
-	num, isNumber := evaluated.(*obj.Number)
-	if !isNumber {
-		return newError("el indice debe ser un enetero")
+	if slice, isSlice := call.Index.(*ast.Slice); isSlice {
+		bounds, err := evaluateSliceBounds(slice, len(list.Values), env)
+		if err != nil {
+			return err
+		}
+
+		sliced := &obj.List{Values: []obj.Object{}}
+		for _, index := range bounds.indexes() {
+			sliced.Values = append(sliced.Values, list.Values[index])
+		}
+
+		return sliced
 	}
 
-	length := len(list.Values)
-	index, err := checkIndex(length, num.Value)
+	index, err := checkIndexObject(Evaluate(call.Index, env), len(list.Values))
 	if err != nil {
 		return err
 	}
@@ -525,19 +798,27 @@ func evaluateListCall(list *obj.List, call *ast.CallList, env *obj.Enviroment) o
 }
 
 func evaluateStringCall(str *obj.String, call *ast.CallList, env *obj.Enviroment) obj.Object {
-	evaluated := Evaluate(call.Index, env)
-	num, isNumber := evaluated.(*obj.Number)
-	if !isNumber {
-		return newError("El indice debe ser un entero")
+	if slice, isSlice := call.Index.(*ast.Slice); isSlice {
+		runes := []rune(str.Value)
+		bounds, err := evaluateSliceBounds(slice, len(runes), env)
+		if err != nil {
+			return err
+		}
+
+		var sliced strings.Builder
+		for _, index := range bounds.indexes() {
+			sliced.WriteRune(runes[index])
+		}
+
+		return &obj.String{Value: sliced.String()}
 	}
 
-	strLen := utf8.RuneCountInString(str.Value)
-	index, err := checkIndex(strLen, num.Value)
+	index, err := checkIndexObject(Evaluate(call.Index, env), utf8.RuneCountInString(str.Value))
 	if err != nil {
 		return err
 	}
 
-	return &obj.String{Value: string(str.Value[index])}
+	return &obj.String{Value: string([]rune(str.Value)[index])}
 }
 
 // evaluate an if expression
@@ -2,9 +2,12 @@ package evaluator
 
 import (
 	"aura/src/ast"
+	b "aura/src/builtins"
 	obj "aura/src/object"
 	"fmt"
-	"reflect"
+	"math"
+	"math/big"
+	"time"
 )
 
 // evluate infix expressions between objects
@@ -14,6 +17,16 @@ func evaluateInfixExpression(operator string, left obj.Object, right obj.Object,
 	case left.Type() == obj.INTEGERS && right.Type() == obj.INTEGERS:
 		return evaluateIntegerInfixExpression(operator, left, right)
 
+	case isInteger(left) && isInteger(right):
+		return evaluateBigIntegerInfixExpression(operator, left, right)
+
+	case isNumber(left) && isNumber(right) && (isExactNumber(left) || isExactNumber(right)):
+		return evaluateExactInfixExpression(operator, left, right, env, leftNode)
+
+	case left.Type() == obj.BIGINT && right.Type() == obj.FLOATING,
+		left.Type() == obj.FLOATING && right.Type() == obj.BIGINT:
+		return evaluateInfixExpression(operator, bigIntegerToFloat(left), bigIntegerToFloat(right), env, leftNode)
+
 	case left.Type() == obj.FLOATING && right.Type() == obj.FLOATING:
 		return evaluateFloatInfixExpression(operator, left, right)
 
@@ -29,11 +42,17 @@ func evaluateInfixExpression(operator string, left obj.Object, right obj.Object,
 	case left.Type() == obj.BOOLEAN && right.Type() == obj.BOOLEAN:
 		return evaluateBoolInfixExpression(operator, left.(*obj.Bool), right.(*obj.Bool))
 
+	case isTimeObject(left) || isTimeObject(right):
+		return evaluateTimeInfixExpression(operator, left, right)
+
+	case left.Type() == obj.SET && right.Type() == obj.SET:
+		return evaluateSetInfixExpression(operator, left.(*obj.Set), right.(*obj.Set))
+
 	case operator == "==":
-		return toBooleanObject(reflect.DeepEqual(left, right))
+		return toBooleanObject(obj.Equals(left, right))
 
 	case operator == "!=":
-		return toBooleanObject(!reflect.DeepEqual(left, right))
+		return toBooleanObject(!obj.Equals(left, right))
 
 	case left.Type() != right.Type():
 		return typeMismatchError(
@@ -52,6 +71,42 @@ func evaluateInfixExpression(operator string, left obj.Object, right obj.Object,
 
 }
 
+// evaluate set infix expressions, the comparison operators check if
+// a set is a subset or a superset of the other
+func evaluateSetInfixExpression(operator string, left *obj.Set, right *obj.Set) obj.Object {
+	switch operator {
+	case "|":
+		return left.Union(right)
+
+	case "&":
+		return left.Intersection(right)
+
+	case "-":
+		return left.Difference(right)
+
+	case "==":
+		return toBooleanObject(left.Equals(right))
+
+	case "!=":
+		return toBooleanObject(!left.Equals(right))
+
+	case "<=":
+		return toBooleanObject(left.IsSubset(right))
+
+	case ">=":
+		return toBooleanObject(right.IsSubset(left))
+
+	case "<":
+		return toBooleanObject(left.IsSubset(right) && left.Len() < right.Len())
+
+	case ">":
+		return toBooleanObject(right.IsSubset(left) && right.Len() < left.Len())
+
+	default:
+		return unknownInfixOperator(obj.Types[left.Type()], operator, obj.Types[right.Type()])
+	}
+}
+
 // evaluate bool infix expressions
 func evaluateBoolInfixExpression(operator string, left *obj.Bool, rigth *obj.Bool) obj.Object {
 	switch operator {
@@ -93,6 +148,8 @@ func evaluateLeftFloatInfixExp(operator string, left obj.Object, rigth obj.Objec
 			return divisionByZeroError()
 		}
 		return obj.NewFloat(leftVal / float64(rigthVal))
+	case "**":
+		return obj.NewFloat(math.Pow(leftVal, float64(rigthVal)))
 
 	case "+=":
 		left.(*obj.Float).Value += float64(rigthVal)
@@ -169,6 +226,8 @@ func evaluateRigthFloatInfixExp(operator string, left obj.Object, rigth obj.Obje
 			return divisionByZeroError()
 		}
 		return obj.NewFloat(float64(leftVal) / rigthVal)
+	case "**":
+		return obj.NewFloat(math.Pow(float64(leftVal), rigthVal))
 
 	case "+=":
 		env.SetItem(variable, obj.NewFloat(float64(leftVal)+rigthVal))
@@ -245,22 +304,29 @@ func evaluateStringInfixExpression(operator string, left obj.Object, rigth obj.O
 
 // evaluate suffix expressions
 func evaluateSuffixExpression(operator string, left obj.Object) obj.Object {
-	if num, isNumber := left.(*obj.Number); isNumber {
+	if isInteger(left) {
+		var result obj.Object
 		switch operator {
 		case "++":
-			num.Value++
-			return num
+			result = evaluateInfixExpression("+", left, &obj.Number{Value: 1}, nil, nil)
 
 		case "--":
-			num.Value--
-			return num
+			result = evaluateInfixExpression("-", left, &obj.Number{Value: 1}, nil, nil)
 
 		case "**":
-			num.Value *= num.Value
-			return num
+			result = evaluateInfixExpression("*", left, left, nil, nil)
+
 		default:
 			return &obj.Error{Message: "Operador desconocido para entero"}
 		}
+
+		if num, isNumber := left.(*obj.Number); isNumber && result.Type() == obj.INTEGERS {
+			num.Value = result.(*obj.Number).Value
+			return num
+		}
+
+		// a big integer, the evaluator reassigns the variable
+		return result
 	}
 
 	return newError(fmt.Sprintf("el operador %s solo puede ser aplicado en numeros", operator))
@@ -281,6 +347,8 @@ func evaluateFloatInfixExpression(operator string, left, rigth obj.Object) obj.O
 			return divisionByZeroError()
 		}
 		return obj.NewFloat(leftVal / rigthVal)
+	case "**":
+		return obj.NewFloat(math.Pow(leftVal, rigthVal))
 	case "+=":
 		left.(*obj.Float).Value += rigthVal
 		return left
@@ -323,43 +391,69 @@ func evaluateFloatInfixExpression(operator string, left, rigth obj.Object) obj.O
 	}
 }
 
-// evluate infix integer expressions
+// evluate infix integer expressions, if the result overflows an int the
+// operation is done again with big integers
 func evaluateIntegerInfixExpression(operator string, left, rigth obj.Object) obj.Object {
 	leftVal := left.(*obj.Number).Value
 	rigthVal := rigth.(*obj.Number).Value
 
 	switch operator {
 	case "+":
-		return &obj.Number{Value: leftVal + rigthVal}
+		if result := leftVal + rigthVal; (result > leftVal) == (rigthVal > 0) {
+			return &obj.Number{Value: result}
+		}
+
+		return evaluateBigIntegerInfixExpression(operator, left, rigth)
+
 	case "-":
-		return &obj.Number{Value: leftVal - rigthVal}
+		if result := leftVal - rigthVal; (result < leftVal) == (rigthVal > 0) {
+			return &obj.Number{Value: result}
+		}
+
+		return evaluateBigIntegerInfixExpression(operator, left, rigth)
+
 	case "*":
-		return &obj.Number{Value: leftVal * rigthVal}
+		if leftVal == 0 || rigthVal == 0 {
+			return &obj.Number{Value: 0}
+		}
+
+		result := leftVal * rigthVal
+		if result/rigthVal == leftVal && !isMinIntByMinusOne(leftVal, rigthVal) {
+			return &obj.Number{Value: result}
+		}
+
+		return evaluateBigIntegerInfixExpression(operator, left, rigth)
+
 	case "/":
-		if leftVal == 0 && rigthVal == 0 {
+		if rigthVal == 0 {
 			return newError("division entre 0 ")
 		}
+
+		if isMinIntByMinusOne(leftVal, rigthVal) {
+			return evaluateBigIntegerInfixExpression(operator, left, rigth)
+		}
+
 		return &obj.Number{Value: leftVal / rigthVal}
+
 	case "%":
+		if rigthVal == 0 {
+			return divisionByZeroError()
+		}
+
 		return &obj.Number{Value: leftVal % rigthVal}
-	case "+=":
-		left.(*obj.Number).Value += rigthVal
-		return left
 
-	case "-=":
-		left.(*obj.Number).Value -= rigthVal
-		return left
+	case "**":
+		return evaluateBigIntegerInfixExpression(operator, left, rigth)
 
-	case "/=":
-		if left.(*obj.Number).Value == 0 && rigthVal == 0 {
-			return divisionByZeroError()
+	case "+=", "-=", "/=", "*=":
+		result := evaluateIntegerInfixExpression(operator[:1], left, rigth)
+		if num, isNum := result.(*obj.Number); isNum {
+			left.(*obj.Number).Value = num.Value
+			return left
 		}
-		left.(*obj.Number).Value /= rigthVal
-		return left
 
-	case "*=":
-		left.(*obj.Number).Value *= rigthVal
-		return left
+		// an error or a big integer, the evaluator reassigns the variable
+		return result
 
 	case ">":
 		return toBooleanObject(leftVal > rigthVal)
@@ -383,13 +477,337 @@ func evaluateIntegerInfixExpression(operator string, left, rigth obj.Object) obj
 	}
 }
 
+// check if the operation is the smallest int divided or multiplied by -1,
+// the only case where the result of the division overflows
+func isMinIntByMinusOne(left, rigth int) bool {
+	return (left == math.MinInt && rigth == -1) || (rigth == math.MinInt && left == -1)
+}
+
+// evaluate infix expressions where at least one integer is a big integer,
+// the result is demoted to a normal integer if it fits again
+func evaluateBigIntegerInfixExpression(operator string, left, rigth obj.Object) obj.Object {
+	leftVal, _ := obj.ToBigInt(left)
+	rigthVal, _ := obj.ToBigInt(rigth)
+
+	switch operator {
+	case "+":
+		return obj.NewInteger(new(big.Int).Add(leftVal, rigthVal))
+	case "-":
+		return obj.NewInteger(new(big.Int).Sub(leftVal, rigthVal))
+	case "*":
+		return obj.NewInteger(new(big.Int).Mul(leftVal, rigthVal))
+	case "/":
+		if rigthVal.Sign() == 0 {
+			return newError("division entre 0 ")
+		}
+
+		return obj.NewInteger(new(big.Int).Quo(leftVal, rigthVal))
+
+	case "%":
+		if rigthVal.Sign() == 0 {
+			return divisionByZeroError()
+		}
+
+		return obj.NewInteger(new(big.Int).Rem(leftVal, rigthVal))
+
+	case "**":
+		if rigthVal.Sign() < 0 {
+			// a negative exponent returns a fraction
+			base, _ := new(big.Float).SetInt(leftVal).Float64()
+			exponent, _ := new(big.Float).SetInt(rigthVal).Float64()
+			return obj.NewFloat(math.Pow(base, exponent))
+		}
+
+		if obj.PowerTooLarge(leftVal, rigthVal) {
+			return powerTooLarge()
+		}
+
+		return obj.NewInteger(new(big.Int).Exp(leftVal, rigthVal, nil))
+
+	case "+=", "-=", "/=", "*=":
+		// the big integers are not modified, the evaluator reassigns the variable
+		return evaluateBigIntegerInfixExpression(operator[:1], left, rigth)
+
+	case ">":
+		return toBooleanObject(leftVal.Cmp(rigthVal) > 0)
+	case "<":
+		return toBooleanObject(leftVal.Cmp(rigthVal) < 0)
+	case "==":
+		return toBooleanObject(leftVal.Cmp(rigthVal) == 0)
+	case "!=":
+		return toBooleanObject(leftVal.Cmp(rigthVal) != 0)
+	case ">=":
+		return toBooleanObject(leftVal.Cmp(rigthVal) >= 0)
+	case "<=":
+		return toBooleanObject(leftVal.Cmp(rigthVal) <= 0)
+
+	default:
+		return unknownInfixOperator(
+			obj.Types[left.Type()],
+			operator,
+			obj.Types[rigth.Type()],
+		)
+	}
+}
+
+// check if the object is a number or a big integer
+func isInteger(object obj.Object) bool {
+	return object.Type() == obj.INTEGERS || object.Type() == obj.BIGINT
+}
+
+// convert a big integer to a float, the other objects are not changed
+func bigIntegerToFloat(object obj.Object) obj.Object {
+	if bigInt, isBig := object.(*obj.BigInteger); isBig {
+		value, _ := new(big.Float).SetInt(bigInt.Value).Float64()
+		return obj.NewFloat(value)
+	}
+
+	return object
+}
+
+// the decimals kept when the division of two decimals does not terminate
+const decimalDivisionPrecision = 28
+
+// check if the object is a decimal or a fraction
+func isExactNumber(object obj.Object) bool {
+	return object.Type() == obj.DECIMAL || object.Type() == obj.FRACTION
+}
+
+// check if the object is any of the number types
+func isNumber(object obj.Object) bool {
+	return isInteger(object) || isExactNumber(object) || object.Type() == obj.FLOATING
+}
+
+// evaluate infix expressions where at least one number is a decimal or a
+// fraction. a float makes the result a float, a fraction makes the result
+// a fraction and the integers are promoted to decimals or fractions
+func evaluateExactInfixExpression(operator string, left, rigth obj.Object, env *obj.Enviroment, leftNode ast.Expression) obj.Object {
+	if left.Type() == obj.FLOATING || rigth.Type() == obj.FLOATING {
+		return evaluateInfixExpression(operator, exactToFloat(left), exactToFloat(rigth), env, leftNode)
+	}
+
+	leftVal, _ := obj.ToRat(left)
+	rigthVal, _ := obj.ToRat(rigth)
+
+	switch operator {
+	case "+=", "-=", "/=", "*=":
+		// the exact numbers are not modified, the evaluator reassigns the variable
+		return evaluateExactInfixExpression(operator[:1], left, rigth, env, leftNode)
+
+	case ">":
+		return toBooleanObject(leftVal.Cmp(rigthVal) > 0)
+	case "<":
+		return toBooleanObject(leftVal.Cmp(rigthVal) < 0)
+	case "==":
+		return toBooleanObject(leftVal.Cmp(rigthVal) == 0)
+	case "!=":
+		return toBooleanObject(leftVal.Cmp(rigthVal) != 0)
+	case ">=":
+		return toBooleanObject(leftVal.Cmp(rigthVal) >= 0)
+	case "<=":
+		return toBooleanObject(leftVal.Cmp(rigthVal) <= 0)
+	}
+
+	if left.Type() == obj.FRACTION || rigth.Type() == obj.FRACTION {
+		return evaluateFractionInfixExpression(operator, left, rigth)
+	}
+
+	return evaluateDecimalInfixExpression(operator, toDecimal(left), toDecimal(rigth))
+}
+
+// evaluate the arithmetic operators between two fractions
+func evaluateFractionInfixExpression(operator string, left, rigth obj.Object) obj.Object {
+	leftVal, _ := obj.ToRat(left)
+	rigthVal, _ := obj.ToRat(rigth)
+
+	switch operator {
+	case "+":
+		return obj.NewFraction(new(big.Rat).Add(leftVal, rigthVal))
+	case "-":
+		return obj.NewFraction(new(big.Rat).Sub(leftVal, rigthVal))
+	case "*":
+		return obj.NewFraction(new(big.Rat).Mul(leftVal, rigthVal))
+	case "/":
+		if rigthVal.Sign() == 0 {
+			return newError("division entre 0 ")
+		}
+
+		return obj.NewFraction(new(big.Rat).Quo(leftVal, rigthVal))
+
+	case "%":
+		if rigthVal.Sign() == 0 {
+			return divisionByZeroError()
+		}
+
+		return obj.NewFraction(ratRemainder(leftVal, rigthVal))
+
+	case "**":
+		result, err := ratPower(leftVal, rigth)
+		if err != nil {
+			return err
+		}
+
+		return obj.NewFraction(result)
+
+	default:
+		return unknownInfixOperator(obj.Types[left.Type()], operator, obj.Types[rigth.Type()])
+	}
+}
+
+// evaluate the arithmetic operators between two decimals, the sum keeps
+// the biggest scale and the product adds the scales like on paper
+func evaluateDecimalInfixExpression(operator string, left, rigth *obj.Decimal) obj.Object {
+	scale := left.Scale
+	if rigth.Scale > scale {
+		scale = rigth.Scale
+	}
+
+	switch operator {
+	case "+":
+		return obj.NewDecimal(new(big.Rat).Add(left.Value, rigth.Value), scale)
+	case "-":
+		return obj.NewDecimal(new(big.Rat).Sub(left.Value, rigth.Value), scale)
+	case "*":
+		return obj.NewDecimal(new(big.Rat).Mul(left.Value, rigth.Value), left.Scale+rigth.Scale)
+	case "/":
+		if rigth.Value.Sign() == 0 {
+			return newError("division entre 0 ")
+		}
+
+		return divideDecimal(new(big.Rat).Quo(left.Value, rigth.Value), left.Scale)
+
+	case "%":
+		if rigth.Value.Sign() == 0 {
+			return divisionByZeroError()
+		}
+
+		return obj.NewDecimal(ratRemainder(left.Value, rigth.Value), scale)
+
+	case "**":
+		result, err := ratPower(left.Value, rigth)
+		if err != nil {
+			return err
+		}
+
+		if rigth.Value.Sign() < 0 {
+			return divideDecimal(result, 0)
+		}
+
+		// the scale grows with the exponent even if the value does not, like 1.0 ** n
+		exponent := int(rigth.Value.Num().Int64())
+		if left.Scale > 0 && exponent > obj.MaxPowerBits/left.Scale {
+			return powerTooLarge()
+		}
+
+		return obj.NewDecimal(result, left.Scale*exponent)
+
+	default:
+		return unknownInfixOperator(obj.Types[left.Type()], operator, obj.Types[rigth.Type()])
+	}
+}
+
+// return the quotient of a decimal division, the result keeps all the decimals
+// if the division terminates or is rounded to the decimal division precision
+func divideDecimal(quotient *big.Rat, minScale int) *obj.Decimal {
+	for scale := minScale; scale <= decimalDivisionPrecision; scale++ {
+		if obj.RoundRat(quotient, scale, obj.TRUNCATE).Cmp(quotient) == 0 {
+			return obj.NewDecimal(quotient, scale)
+		}
+	}
+
+	rounded := obj.RoundRat(quotient, decimalDivisionPrecision, obj.HALFEVEN)
+	return obj.NewDecimal(rounded, decimalDivisionPrecision)
+}
+
+// return the remainder of the division truncated towards zero, like the
+// remainder of the integers
+func ratRemainder(left, rigth *big.Rat) *big.Rat {
+	quotient := new(big.Rat).Quo(left, rigth)
+	truncated := new(big.Int).Quo(quotient.Num(), quotient.Denom())
+	product := new(big.Rat).Mul(rigth, new(big.Rat).SetInt(truncated))
+	return product.Sub(left, product)
+}
+
+// raise the base to the exponent, the exponent must be an integer
+func ratPower(base *big.Rat, exponent obj.Object) (*big.Rat, *obj.Error) {
+	value, _ := obj.ToRat(exponent)
+	if !value.IsInt() || !value.Num().IsInt64() {
+		return nil, newError("el exponente de un decimal o una fraccion debe ser un entero")
+	}
+
+	power := value.Num().Int64()
+	if power < 0 && base.Sign() == 0 {
+		return nil, newError("division entre 0 ")
+	}
+
+	magnitude := big.NewInt(abs(power))
+	if obj.PowerTooLarge(base.Num(), magnitude) || obj.PowerTooLarge(base.Denom(), magnitude) {
+		return nil, powerTooLarge()
+	}
+
+	result := new(big.Rat).SetFrac(
+		new(big.Int).Exp(base.Num(), magnitude, nil),
+		new(big.Int).Exp(base.Denom(), magnitude, nil),
+	)
+
+	if power < 0 {
+		result.Inv(result)
+	}
+
+	return result, nil
+}
+
+// return the absolute value of the integer
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
+
+// convert a decimal or a fraction to a float, the other objects are not changed
+func exactToFloat(object obj.Object) obj.Object {
+	if !isExactNumber(object) {
+		return bigIntegerToFloat(object)
+	}
+
+	value, _ := obj.ToRat(object)
+	float, _ := value.Float64()
+	return obj.NewFloat(float)
+}
+
+// convert an integer to a decimal with scale 0
+func toDecimal(object obj.Object) *obj.Decimal {
+	if decimal, isDecimal := object.(*obj.Decimal); isDecimal {
+		return decimal
+	}
+
+	value, _ := obj.ToRat(object)
+	return obj.NewDecimal(value, 0)
+}
+
 // check that the character after - is a number and apply the operator
 func evaluateMinusOperatorExpression(rigth obj.Object) obj.Object {
 	switch num := rigth.(type) {
 	case *obj.Number:
+		if num.Value == math.MinInt {
+			// the negative of the smallest int does not fit in an int
+			return obj.NewInteger(new(big.Int).Neg(big.NewInt(int64(num.Value))))
+		}
+
 		num.Value = -num.Value
 		return num
 
+	case *obj.BigInteger:
+		return obj.NewInteger(new(big.Int).Neg(num.Value))
+
+	case *obj.Decimal:
+		return obj.NewDecimal(new(big.Rat).Neg(num.Value), num.Scale)
+
+	case *obj.Fraction:
+		return obj.NewFraction(new(big.Rat).Neg(num.Value))
+
 	case *obj.Float:
 		num.Value = -num.Value
 		return num
@@ -429,3 +847,123 @@ func evaluateBangOperatorExpression(rigth obj.Object) obj.Object {
 		return obj.SingletonFALSE
 	}
 }
+
+// check if the object is a date or a duration
+func isTimeObject(object obj.Object) bool {
+	return object.Type() == obj.DATE || object.Type() == obj.DURATION
+}
+
+// evaluate infix expressions between dates and durations like:
+//		fecha + tiempo.dias(1)
+//		fin - inicio
+func evaluateTimeInfixExpression(operator string, left, rigth obj.Object) obj.Object {
+	switch left := left.(type) {
+	case *obj.Date:
+		switch rigth := rigth.(type) {
+		case *obj.Date:
+			if operator == "-" {
+				return b.NewDuration(left.Value.Sub(rigth.Value))
+			}
+
+			return compareTime(operator, compareDurations(left.Value.Sub(rigth.Value), 0), left, rigth)
+
+		case *obj.Duration:
+			switch operator {
+			case "+":
+				return b.NewDate(left.Value.Add(rigth.Value))
+			case "-":
+				return b.NewDate(left.Value.Add(-rigth.Value))
+			}
+		}
+
+	case *obj.Duration:
+		switch rigth := rigth.(type) {
+		case *obj.Duration:
+			switch operator {
+			case "+":
+				return b.NewDuration(left.Value + rigth.Value)
+			case "-":
+				return b.NewDuration(left.Value - rigth.Value)
+			case "/":
+				if rigth.Value == 0 {
+					return divisionByZeroError()
+				}
+
+				return obj.NewFloat(float64(left.Value) / float64(rigth.Value))
+			}
+
+			return compareTime(operator, compareDurations(left.Value, rigth.Value), left, rigth)
+
+		case *obj.Date:
+			if operator == "+" {
+				return b.NewDate(rigth.Value.Add(left.Value))
+			}
+
+		case *obj.Number, *obj.Float:
+			factor := numberValue(rigth)
+			switch operator {
+			case "*":
+				return b.NewDuration(time.Duration(float64(left.Value) * factor))
+			case "/":
+				if factor == 0 {
+					return divisionByZeroError()
+				}
+
+				return b.NewDuration(time.Duration(float64(left.Value) / factor))
+			}
+		}
+
+	case *obj.Number, *obj.Float:
+		if duration, isDuration := rigth.(*obj.Duration); isDuration && operator == "*" {
+			factor := numberValue(left)
+			return b.NewDuration(time.Duration(factor * float64(duration.Value)))
+		}
+	}
+
+	return compareTime(operator, 0, left, rigth)
+}
+
+// return -1 if the left duration is shorter, 1 if is longer and 0 if are equal
+func compareDurations(left, rigth time.Duration) int {
+	switch {
+	case left < rigth:
+		return -1
+	case left > rigth:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// return the value of an integer or a float as a float
+func numberValue(number obj.Object) float64 {
+	if integer, isInt := number.(*obj.Number); isInt {
+		return float64(integer.Value)
+	}
+
+	return number.(*obj.Float).Value
+}
+
+// evaluate the comparison operators with the result of comparing two times,
+// the values of different types are never equal
+func compareTime(operator string, comparison int, left, rigth obj.Object) obj.Object {
+	sameType := left.Type() == rigth.Type()
+	switch {
+	case operator == "==":
+		return toBooleanObject(sameType && comparison == 0)
+	case operator == "!=":
+		return toBooleanObject(!sameType || comparison != 0)
+	case !sameType:
+		return typeMismatchError(obj.Types[left.Type()], operator, obj.Types[rigth.Type()])
+	case operator == "<":
+		return toBooleanObject(comparison < 0)
+	case operator == "<=":
+		return toBooleanObject(comparison <= 0)
+	case operator == ">":
+		return toBooleanObject(comparison > 0)
+	case operator == ">=":
+		return toBooleanObject(comparison >= 0)
+	default:
+		return unknownInfixOperator(obj.Types[left.Type()], operator, obj.Types[rigth.Type()])
+	}
+}
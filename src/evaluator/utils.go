@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"aura/src/ast"
 	"aura/src/lexer"
 	obj "aura/src/object"
 	"aura/src/parser"
@@ -8,6 +9,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -41,14 +43,16 @@ func importEnv(path string) (*obj.Enviroment, *obj.Error) {
 		))
 	}
 
-	// read the file
-	content, err := os.ReadFile(path)
+	// open the file, the lexer reads it while parsing
+	file, err := os.Open(path)
 	if err != nil {
 		return nil, newError(fmt.Sprintf("No se leer el archivo %s", filepath.Base(path)))
 	}
 
+	defer file.Close()
+
 	// parse and evaluate the file
-	lexer := lexer.NewLexer(string(content))
+	lexer := lexer.NewReaderLexer(file)
 	parser := parser.NewParser(lexer)
 	env := obj.NewEnviroment(nil)
 	program := parser.ParseProgam()
@@ -85,3 +89,211 @@ func checkIndex(length int, index int) (int, *obj.Error) {
 
 	return index, nil
 }
+
+// check that the evaluated index is an integer valid for a data structure
+// with the given length, a big integer is always out of range
+func checkIndexObject(index obj.Object, length int) (int, *obj.Error) {
+	switch index := index.(type) {
+	case *obj.Number:
+		return checkIndex(length, index.Value)
+
+	case *obj.BigInteger:
+		return 0, indexOutOfRange(index.Inspect(), length)
+
+	default:
+		return 0, newError("El indice debe ser un entero")
+	}
+}
+
+// represents the evaluated parts of a slice
+type sliceBounds struct {
+	start int // represents the first index
+	end   int // represents the index where the slice ends, not included
+	step  int // represents the distance between the indexes
+}
+
+// evaluate the parts of the slice for a data structure with the given length.
+// the negative indexes are counted from the end like in a list call and the
+// indexes out of range are limited to the data structure like in rebanar
+func evaluateSliceBounds(slice *ast.Slice, length int, env *obj.Enviroment) (*sliceBounds, *obj.Error) {
+	bounds := &sliceBounds{start: 0, end: length, step: 1}
+	if slice.Step != nil {
+		step, err := evaluateSliceIndex(slice.Step, env)
+		if err != nil {
+			return nil, err
+		}
+
+		if step == 0 {
+			return nil, newError("El paso de una rebanada no puede ser 0")
+		}
+
+		bounds.step = step
+	}
+
+	if bounds.step < 0 {
+		// the slice goes from the end to the start
+		bounds.start, bounds.end = length-1, -1
+	}
+
+	if slice.Start != nil {
+		start, err := evaluateSliceIndex(slice.Start, env)
+		if err != nil {
+			return nil, err
+		}
+
+		bounds.start = clampSliceIndex(length, start, bounds.step)
+	}
+
+	if slice.End != nil {
+		end, err := evaluateSliceIndex(slice.End, env)
+		if err != nil {
+			return nil, err
+		}
+
+		bounds.end = clampSliceIndex(length, end, bounds.step)
+	}
+
+	return bounds, nil
+}
+
+// evaluate a part of a slice that must be an integer
+func evaluateSliceIndex(expression ast.Expression, env *obj.Enviroment) (int, *obj.Error) {
+	evaluated := Evaluate(expression, env)
+	if err, isErr := evaluated.(*obj.Error); isErr {
+		return 0, err
+	}
+
+	switch num := evaluated.(type) {
+	case *obj.Number:
+		return num.Value, nil
+
+	case *obj.BigInteger:
+		// the big integers are out of range, so they are clamped to the ends
+		if num.Value.Sign() < 0 {
+			return math.MinInt, nil
+		}
+
+		return math.MaxInt, nil
+
+	default:
+		return 0, newError("los indices de una rebanada deben ser enteros")
+	}
+}
+
+// convert a negative index of a slice to an index from the start and limit
+// it to the range between 0 and the length, with a negative step the range
+// is between -1 and the last index because the slice goes backwards
+func clampSliceIndex(length int, index int, step int) int {
+	if index < 0 {
+		index += length
+	}
+
+	low, high := 0, length
+	if step < 0 {
+		low, high = -1, length-1
+	}
+
+	if index < low {
+		return low
+	}
+
+	if index > high {
+		return high
+	}
+
+	return index
+}
+
+// return the indexes in the slice
+func (s *sliceBounds) indexes() []int {
+	indexes := []int{}
+	for idx := s.start; (s.step > 0 && idx < s.end) || (s.step < 0 && idx > s.end); idx += s.step {
+		indexes = append(indexes, idx)
+	}
+
+	return indexes
+}
+
+// check if the operator modifies the variable at the left like +=
+func isAssignmentOperator(operator string) bool {
+	return operator == "+=" || operator == "-=" || operator == "*=" || operator == "/="
+}
+
+// return an error if the expression is a constant
+func checkConstant(expression ast.Expression, env *obj.Enviroment) *obj.Error {
+	ident, isIdent := expression.(*ast.Identifier)
+	if isIdent && env.IsConstant(ident.Value) {
+		return constantReassignment(ident.Value)
+	}
+
+	return nil
+}
+
+// return an error if the variable is a constant defined in the same scope,
+// a constant of an outer scope can be shadowed
+func checkLocalConstant(name string, env *obj.Enviroment) *obj.Error {
+	if _, isLocal := env.Store[name]; isLocal && env.IsConstant(name) {
+		return constantReassignment(name)
+	}
+
+	return nil
+}
+
+// check if the list, map or set was frozen with congelar
+func isFrozen(object obj.Object) bool {
+	switch object := object.(type) {
+	case *obj.List:
+		return object.Frozen
+
+	case *obj.Map:
+		return object.Frozen
+
+	case *obj.Set:
+		return object.Frozen
+
+	default:
+		return false
+	}
+}
+
+// the methods that modify the data structure, they can not be used
+// with a frozen data structure
+var mutatingMethods = map[obj.MethodsTypes]bool{
+	obj.POP:    true,
+	obj.APPEND: true,
+	obj.REMOVE: true,
+	obj.SORT:   true,
+	obj.INSERT: true,
+	obj.EXTEND: true,
+	obj.DELETE: true,
+	obj.UPDATE: true,
+	obj.CLEAR:  true,
+}
+
+// the operators like += modify the value of the variable, but when the
+// result is a new object like a promoted big integer the variable is reassigned
+func reassignIfReplaced(expression ast.Expression, previous obj.Object, result obj.Object, env *obj.Enviroment) {
+	ident, isIdent := expression.(*ast.Identifier)
+	if _, isErr := result.(*obj.Error); isErr || !isIdent || result == previous {
+		return
+	}
+
+	env.Reassign(ident.Value, result)
+}
+
+// evaluate each part of the interpolated string in the current enviroment
+// and join the results
+func evaluateInterpolatedString(node *ast.InterpolatedString, env *obj.Enviroment) obj.Object {
+	var buf strings.Builder
+	for _, part := range node.Parts {
+		evaluated := Evaluate(part, env)
+		CheckIsNotNil(evaluated)
+		if err, isError := evaluated.(*obj.Error); isError {
+			return err
+		}
+
+		buf.WriteString(evaluated.Inspect())
+	}
+
+	return &obj.String{Value: buf.String()}
+}
@@ -0,0 +1,129 @@
+package lexer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// represents the reserved words of a language and their token types
+type Keywords map[string]TokenType
+
+// the keyword sets that can be used in a program, a new language is added
+// with a new entry. a file chooses its language with a comment like
+// // idioma: en before its first token
+var Languages = map[string]Keywords{
+	"es": {
+		"falso":     FALSE,
+		"funcion":   FUNCTION,
+		"regresa":   RETURN,
+		"si":        IF,
+		"si_no":     ELSE,
+		"var":       LET,
+		"verdadero": TRUE,
+		"en":        IN,
+		"mientras":  WHILE,
+		"por":       FOR,
+		"lista":     DATASTRCUT,
+		"nulo":      NULLT,
+		"mapa":      MAP,
+		"clase":     CLASS,
+		"nuevo":     NEW,
+		"importar":  IMPORT,
+		"intentar":  TRY,
+		"excepto":   EXCEPT,
+		"lanzar":    THROW,
+		"continuar": CONTINUE,
+		"romper":    BREAK,
+		"conjunto":  SET,
+		"const":     CONST,
+	},
+	"en": {
+		"false":    FALSE,
+		"function": FUNCTION,
+		"return":   RETURN,
+		"if":       IF,
+		"else":     ELSE,
+		"var":      LET,
+		"true":     TRUE,
+		"in":       IN,
+		"while":    WHILE,
+		"for":      FOR,
+		"list":     DATASTRCUT,
+		"null":     NULLT,
+		"map":      MAP,
+		"class":    CLASS,
+		"new":      NEW,
+		"import":   IMPORT,
+		"try":      TRY,
+		"except":   EXCEPT,
+		"throw":    THROW,
+		"continue": CONTINUE,
+		"break":    BREAK,
+		"set":      SET,
+		"const":    CONST,
+	},
+	"pt": {
+		"falso":      FALSE,
+		"funcao":     FUNCTION,
+		"retorna":    RETURN,
+		"se":         IF,
+		"senao":      ELSE,
+		"var":        LET,
+		"verdadeiro": TRUE,
+		"em":         IN,
+		"enquanto":   WHILE,
+		"para":       FOR,
+		"lista":      DATASTRCUT,
+		"nulo":       NULLT,
+		"mapa":       MAP,
+		"classe":     CLASS,
+		"novo":       NEW,
+		"importar":   IMPORT,
+		"tentar":     TRY,
+		"exceto":     EXCEPT,
+		"lancar":     THROW,
+		"continuar":  CONTINUE,
+		"parar":      BREAK,
+		"conjunto":   SET,
+		"const":      CONST,
+	},
+}
+
+// the language used by the lexers that do not choose one
+var defaultLanguage = "es"
+
+// the prefix of the comment that chooses the language of a file
+const languageDirective = "idioma:"
+
+// set the language used by the lexers that do not choose one
+func SetDefaultLanguage(language string) error {
+	if _, exists := Languages[language]; !exists {
+		return fmt.Errorf("%s", unknownLanguage(language))
+	}
+
+	defaultLanguage = language
+	return nil
+}
+
+func unknownLanguage(language string) string {
+	names := make([]string, 0, len(Languages))
+	for name := range Languages {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return fmt.Sprintf("idioma desconocido %s, los idiomas son %s", language, strings.Join(names, ", "))
+}
+
+// return the reserved word of the token type in the keywords or the name
+// of the token if it is not a reserved word
+func (k Keywords) name(tokenType TokenType) string {
+	for word, wordType := range k {
+		if wordType == tokenType {
+			return word
+		}
+	}
+
+	return Tokens[tokenType]
+}
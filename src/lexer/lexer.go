@@ -2,23 +2,31 @@ package lexer
 
 import (
 	"fmt"
-	"regexp"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
-var (
-	charRegex    = regexp.MustCompile(`^[a-záéíóúA-ZÁÉÍÓÚñÑ_]$`)
-	numRegex     = regexp.MustCompile(`^\d$`)
-	wSpaceRegex  = regexp.MustCompile(`^\s$`)
-	newLineRegex = regexp.MustCompile(`^\n$`)
-)
+// the minimum number of bytes requested to the reader at once
+const chunkSize = 4096
 
 // Represents the lexer of the programming lenguage
 type Lexer struct {
-	source        string // represents the source code
-	character     string // represents the current character
-	read_position int    // represents the next position in the current source
-	position      int    // represents the current postion in source read
+	source        string    // represents the source code that has not been discarded
+	reader        io.Reader // represents the source code not read yet, nil when the source is complete
+	character     string    // represents the current character
+	read_position int       // represents the byte offset of the next character
+	position      int       // represents the byte offset of the current character
+	line          int       // represents the line of the current character
+	column        int       // represents the column of the current character
+	tokenLine     int       // represents the line where the current token starts
+	tokenColumn   int       // represents the column where the current token starts
+	doc           []string  // represents the lines of the doc comment before the next token
+	keywords      Keywords  // represents the reserved words of the language of the source
+	started       bool      // represents if a token was already returned
+	errors        []string  // represents the errors found while reading the source
 }
 
 // create a new lexer
@@ -28,34 +36,96 @@ func NewLexer(source string) *Lexer {
 		character:     "",
 		read_position: 0,
 		position:      0,
+		line:          1,
+		keywords:      Languages[defaultLanguage],
 	}
 
 	lexer.readCharacter()
 	return lexer
 }
 
-// read next token and assing a token type to the token
+// create a lexer that reads the source code from the reader while the
+// tokens are requested, the source of the returned tokens is discarded
+func NewReaderLexer(reader io.Reader) *Lexer {
+	lexer := &Lexer{
+		source:   "",
+		reader:   reader,
+		line:     1,
+		keywords: Languages[defaultLanguage],
+	}
+
+	lexer.readCharacter()
+	return lexer
+}
+
+// create a lexer for a piece of the source, like an expression in an
+// interpolated string, that uses the same reserved words
+func (l *Lexer) Sublexer(source string) *Lexer {
+	lexer := NewLexer(source)
+	lexer.keywords = l.keywords
+	return lexer
+}
+
+// return the reserved word of the token type in the language of the source
+func (l *Lexer) TokenName(tokenType TokenType) string {
+	return l.keywords.name(tokenType)
+}
+
+// read next token and set the position where the token starts
 func (l *Lexer) NextToken() *Token {
+	token := l.nextToken()
+	l.started = true
+	token.Line, token.Column = l.tokenLine, l.tokenColumn
+	token.Doc = strings.Join(l.doc, "\n")
+	l.doc = nil
+	return token
+}
+
+// read next token and assing a token type to the token
+func (l *Lexer) nextToken() *Token {
 	l.skipWhiteSpaces()
-	if l.isLetter(l.character) {
+	l.discard()
+	l.tokenLine, l.tokenColumn = l.line, l.column
+	if (l.character == "f" || l.character == "r") && isQuote(l.peekCharacter()) {
+		prefix := l.character
+		l.readCharacter()
+		token := l.readString(prefix == "r", prefix == "f")
+		l.readCharacter()
+		return token
+
+	} else if isIdentifierStart(l.character) {
 		literal := l.readIdentifier()
-		token_type := LookUpTokenType(literal)
-		return NewToken(token_type, literal)
+		if token_type, exists := l.keywords[literal]; exists {
+			return NewToken(token_type, literal)
+		}
+
+		return NewToken(IDENT, literal)
+
+	} else if l.isNumber(l.character) || (l.character == "." && l.isNumber(l.peekCharacter())) {
+		return l.readNumber()
 
-	} else if l.isNumber(l.character) {
-		literal := l.readNumber()
-		if l.character == "." {
-			literal += l.character
+	} else if l.character == "/" {
+		if l.peekCharacter() == "/" && l.peekNthCharacter(2) == "/" && l.peekNthCharacter(3) != "/" {
+			l.readDocComment()
 			l.readCharacter()
-			literal += l.readNumber()
-			return NewToken(FLOAT, literal)
+			return l.nextToken()
 		}
-		return NewToken(INT, literal)
-	} else if l.character == "/" {
+
 		if l.peekCharacter() == "/" {
+			initialPosition := l.position
 			l.skipComment()
+			if !l.started {
+				l.readLanguageDirective(l.slice(initialPosition+2, l.read_position))
+			}
+
+			l.readCharacter()
+			return l.nextToken()
+		}
+
+		if l.peekCharacter() == "*" {
+			l.skipBlockComment()
 			l.readCharacter()
-			return l.NextToken()
+			return l.nextToken()
 		}
 	}
 
@@ -136,7 +206,7 @@ func (l *Lexer) NextToken() *Token {
 		if l.peekCharacter() == "&" {
 			token = l.makeTwoCharacterToken(AND)
 		} else {
-			token = NewToken(ILLEGAL, l.character)
+			token = NewToken(AMPERSAND, l.character)
 		}
 
 	case "-":
@@ -171,13 +241,8 @@ func (l *Lexer) NextToken() *Token {
 			token = NewToken(NOT, l.character)
 		}
 
-	case `"`:
-		literal := l.readString()
-		token = NewToken(STRING, literal)
-
-	case "'":
-		literal := l.readString()
-		token = NewToken(STRING, literal)
+	case `"`, "'":
+		token = l.readString(false, false)
 
 	default:
 		token = NewToken(ILLEGAL, l.character)
@@ -187,86 +252,437 @@ func (l *Lexer) NextToken() *Token {
 	return token
 }
 
-// check if current character is letter
-func (l *Lexer) isLetter(char string) bool {
-	return charRegex.MatchString(char)
+// check if the character can start an identifier, the identifiers follow
+// the unicode rules of UAX #31 and can also start with an underscore
+func isIdentifierStart(char string) bool {
+	if len(char) == 1 {
+		return (char[0] >= 'a' && char[0] <= 'z') || (char[0] >= 'A' && char[0] <= 'Z') || char[0] == '_'
+	}
+
+	letter, _ := utf8.DecodeRuneInString(char)
+	return unicode.In(letter, unicode.L, unicode.Nl, unicode.Other_ID_Start) &&
+		!unicode.In(letter, unicode.Pattern_Syntax, unicode.Pattern_White_Space)
+}
+
+// check if the character can be part of an identifier after the first one
+func isIdentifierPart(char string) bool {
+	if len(char) == 1 {
+		return isIdentifierStart(char) || (char[0] >= '0' && char[0] <= '9')
+	}
+
+	letter, _ := utf8.DecodeRuneInString(char)
+	return isIdentifierStart(char) ||
+		(unicode.In(letter, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc, unicode.Other_ID_Continue) &&
+			!unicode.In(letter, unicode.Pattern_Syntax, unicode.Pattern_White_Space))
 }
 
 // check if current character is number
 func (l *Lexer) isNumber(char string) bool {
-	return numRegex.MatchString(char)
+	return len(char) == 1 && char[0] >= '0' && char[0] <= '9'
+}
+
+// check if the character is a space, a tab or a line break
+func isWhiteSpace(char string) bool {
+	return len(char) == 1 && strings.IndexByte(" \t\n\r\f", char[0]) != -1
 }
 
 // create a two character token
 func (l *Lexer) makeTwoCharacterToken(tokenType TokenType) *Token {
-	prefix := l.character
+	initialPosition := l.position
 	l.readCharacter()
-	suffix := l.character
-	return NewToken(tokenType, fmt.Sprintf("%s%s", prefix, suffix))
+	return NewToken(tokenType, l.source[initialPosition:l.read_position])
 }
 
-// read the current character and advance to  the next character
+// read the current character and advance to the next character, the
+// character is a slice of the source so reading it does not allocate
 func (l *Lexer) readCharacter() {
-	if l.read_position >= utf8.RuneCountInString(l.source) {
+	if l.character == "\n" {
+		l.line++
+		l.column = 0
+	}
+
+	l.position = l.read_position
+	l.fill(l.read_position, utf8.UTFMax)
+	if l.read_position >= len(l.source) {
 		l.character = ""
 	} else {
-		l.character = string([]rune(l.source)[l.read_position])
+		_, size := utf8.DecodeRuneInString(l.source[l.read_position:])
+		l.character = l.source[l.read_position : l.read_position+size]
+		l.read_position += size
 	}
 
-	l.position = l.read_position
-	l.read_position++
+	l.column++
+}
+
+// read from the reader until the source has n bytes after the position or
+// the reader ends, a full rune is always available after a fill
+func (l *Lexer) fill(position, n int) {
+	for l.reader != nil && len(l.source)-position < n {
+		// the chunks grow with the source so a long token is read in linear time
+		size := chunkSize
+		if len(l.source) > size {
+			size = len(l.source)
+		}
+
+		buffer := make([]byte, size)
+		read, err := l.reader.Read(buffer)
+		l.source += string(buffer[:read])
+
+		if err == io.EOF {
+			l.reader = nil
+		} else if err != nil {
+			l.addError(l.line, l.column, fmt.Sprintf("no se pudo leer el codigo: %s", err))
+			l.reader = nil
+		}
+	}
+}
+
+// discard the source before the current character, the positions are
+// moved so they are relative to the current character
+func (l *Lexer) discard() {
+	l.source = l.source[l.position:]
+	l.read_position -= l.position
+	l.position = 0
+}
+
+// return the errors found while reading the source
+func (l *Lexer) Errors() []string {
+	return l.errors
+}
+
+// add an error with the position where it was found
+func (l *Lexer) addError(line, column int, message string) {
+	l.errors = append(l.errors, fmt.Sprintf("%s en la linea %d, columna %d", message, line, column))
 }
 
 // reads the next character until it reaches a newline
 func (l *Lexer) skipComment() {
-	for !newLineRegex.MatchString(l.peekCharacter()) {
+	for l.peekCharacter() != "\n" && l.peekCharacter() != "" {
 		l.readCharacter()
 	}
 }
 
+// choose the reserved words of the file in a comment like // idioma: en,
+// the comment must be written before the first token
+func (l *Lexer) readLanguageDirective(comment string) {
+	comment = strings.TrimSpace(comment)
+	if !strings.HasPrefix(comment, languageDirective) {
+		return
+	}
+
+	language := strings.TrimSpace(strings.TrimPrefix(comment, languageDirective))
+	keywords, exists := Languages[language]
+	if !exists {
+		l.addError(l.tokenLine, l.tokenColumn, unknownLanguage(language))
+		return
+	}
+
+	l.keywords = keywords
+}
+
+// skip a comment like /* ... */, the block comments can be nested
+func (l *Lexer) skipBlockComment() {
+	line, column := l.line, l.column
+	depth := 0
+
+	for l.character != "" {
+		switch {
+		case l.character == "/" && l.peekCharacter() == "*":
+			depth++
+			l.readCharacter()
+
+		case l.character == "*" && l.peekCharacter() == "/":
+			depth--
+			l.readCharacter()
+			if depth == 0 {
+				return
+			}
+		}
+
+		l.readCharacter()
+	}
+
+	l.addError(line, column, "comentario sin terminar")
+}
+
+// read a doc comment like /// documentation, the consecutive lines are
+// attached to the next token
+func (l *Lexer) readDocComment() {
+	l.readCharacter()
+	l.readCharacter()
+	initialPosition := l.read_position
+	l.skipComment()
+
+	line := l.slice(initialPosition, l.read_position)
+	l.doc = append(l.doc, strings.TrimPrefix(line, " "))
+}
+
 // read character sequence
 func (l *Lexer) readIdentifier() string {
 	initialPosition := l.position
-	for l.isLetter(l.character) || l.isNumber(l.character) {
+	for isIdentifierPart(l.character) {
 		l.readCharacter()
 	}
 
-	return l.source[initialPosition:l.position]
+	return l.slice(initialPosition, l.position)
 }
 
-// read a sequence of digits characters
-func (l *Lexer) readNumber() string {
+// read a number like 1_000, 0xFF, 0b1010, 0o17, 1.5e-3 or .5, the parser
+// checks that the digits are valid and the value is in range
+func (l *Lexer) readNumber() *Token {
 	initialPosition := l.position
-	for l.isNumber(l.character) {
+	if l.character == "0" && strings.Contains("xXbBoO", l.peekCharacter()) && l.peekCharacter() != "" {
 		l.readCharacter()
+		l.readCharacter()
+		for isIdentifierPart(l.character) {
+			l.readCharacter()
+		}
+
+		return NewToken(INT, l.slice(initialPosition, l.position))
+	}
+
+	tokenType := INT
+	l.readDigits()
+	if l.character == "." && l.isNumber(l.peekCharacter()) {
+		tokenType = FLOAT
+		l.readCharacter()
+		l.readDigits()
+	}
+
+	sign := l.peekCharacter() == "+" || l.peekCharacter() == "-"
+	if (l.character == "e" || l.character == "E") &&
+		(l.isNumber(l.peekCharacter()) || (sign && l.isNumber(l.peekNthCharacter(2)))) {
+		tokenType = FLOAT
+		l.readCharacter()
+		if sign {
+			l.readCharacter()
+		}
+
+		l.readDigits()
+	}
+
+	return NewToken(tokenType, l.slice(initialPosition, l.position))
+}
+
+// read a sequence of digits and underscores
+func (l *Lexer) readDigits() {
+	for l.isNumber(l.character) || l.character == "_" {
+		l.readCharacter()
+	}
+}
+
+// read a string delimited by the current quote or by three quotes, the
+// escape sequences are replaced unless the string is raw. the expressions
+// between braces in an interpolated string are returned as separated parts
+func (l *Lexer) readString(raw, interpolated bool) *Token {
+	line, column := l.line, l.column
+	delimiter := l.character
+	if l.peekCharacter() == delimiter && l.peekNthCharacter(2) == delimiter {
+		delimiter = strings.Repeat(delimiter, 3)
+		l.readCharacter()
+		l.readCharacter()
+	}
+
+	initialPosition := l.read_position
+	var parts []StringPart
+	var text strings.Builder
+
+	for l.readCharacter(); !l.isDelimiter(delimiter); l.readCharacter() {
+		if l.character == "" {
+			l.addError(line, column, "texto sin terminar")
+			break
+		}
+
+		switch {
+		case l.character == "\\" && raw:
+			// a raw string keeps the backslash but the quote does not end the string
+			text.WriteString(l.character)
+			if l.peekCharacter() == delimiter[:1] || l.peekCharacter() == "\\" {
+				l.readCharacter()
+				text.WriteString(l.character)
+			}
+
+		case l.character == "\\":
+			text.WriteString(l.readEscapeSequence())
+
+		case interpolated && (l.character == "{" || l.character == "}") && l.peekCharacter() == l.character:
+			text.WriteString(l.character)
+			l.readCharacter()
+
+		case interpolated && l.character == "{":
+			if text.Len() != 0 {
+				parts = append(parts, StringPart{Value: text.String()})
+				text.Reset()
+			}
+
+			parts = append(parts, StringPart{Value: l.readInterpolation(), IsExpression: true})
+
+		default:
+			text.WriteString(l.character)
+		}
+	}
+
+	if !interpolated {
+		return NewToken(STRING, text.String())
+	}
+
+	if text.Len() != 0 {
+		parts = append(parts, StringPart{Value: text.String()})
+	}
+
+	token := NewToken(FSTRING, l.slice(initialPosition, l.position))
+	token.Parts = parts
+	return token
+}
+
+// check if the current character starts the delimiter, the last characters
+// of a delimiter with three quotes are skipped
+func (l *Lexer) isDelimiter(delimiter string) bool {
+	if delimiter == "" || l.character != delimiter[:1] {
+		return false
+	}
+
+	if len(delimiter) == 1 {
+		return true
 	}
-	return l.source[initialPosition:l.position]
+
+	if l.peekCharacter() != l.character || l.peekNthCharacter(2) != l.character {
+		return false
+	}
+
+	l.readCharacter()
+	l.readCharacter()
+	return true
 }
 
-// read string will read a string literal
-func (l *Lexer) readString() string {
+// the characters that replace each escape sequence
+var escapeSequences = map[string]string{
+	"n":  "\n",
+	"t":  "\t",
+	"r":  "\r",
+	"0":  "\x00",
+	"\\": "\\",
+	`"`:  `"`,
+	"'":  "'",
+	"{":  "{",
+	"}":  "}",
+	"\n": "",
+}
+
+// read the escape sequence after a backslash and return the characters it
+// represents, the unknown sequences are kept as they are written
+func (l *Lexer) readEscapeSequence() string {
+	line, column := l.line, l.column
 	l.readCharacter()
-	initialPosition := l.position
+	if replacement, exists := escapeSequences[l.character]; exists {
+		return replacement
+	}
+
+	if l.character != "u" {
+		return "\\" + l.character
+	}
+
+	// an unicode escape like \u00f1 or \u{1F600}
+	var digits string
+	if l.peekCharacter() == "{" {
+		l.readCharacter()
+		for l.peekCharacter() != "}" && l.peekCharacter() != "" && len(digits) <= 6 {
+			l.readCharacter()
+			digits += l.character
+		}
 
-	for l.character != `"` && l.character != "'" && l.read_position <= utf8.RuneCountInString(l.source) {
 		l.readCharacter()
+	} else {
+		for i := 0; i < 4 && l.peekCharacter() != ""; i++ {
+			l.readCharacter()
+			digits += l.character
+		}
 	}
 
-	return l.source[initialPosition:l.position]
+	code, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		l.addError(line, column, fmt.Sprintf("secuencia de escape unicode no valida \\u%s", digits))
+		return ""
+	}
+
+	return string(rune(code))
+}
+
+// read the source code of an expression between braces in an interpolated
+// string, the braces and the strings inside the expression are skipped
+func (l *Lexer) readInterpolation() string {
+	initialPosition := l.read_position
+	depth := 0
+
+	for l.readCharacter(); l.character != ""; l.readCharacter() {
+		switch l.character {
+		case "{":
+			depth++
+
+		case "}":
+			if depth == 0 {
+				return l.slice(initialPosition, l.position)
+			}
+
+			depth--
+
+		case `"`, "'":
+			quote := l.character
+			for l.readCharacter(); l.character != quote && l.character != ""; l.readCharacter() {
+				if l.character == "\\" {
+					l.readCharacter()
+				}
+			}
+		}
+	}
+
+	return l.slice(initialPosition, l.position)
+}
+
+// check if the character starts a string
+func isQuote(char string) bool {
+	return char == `"` || char == "'"
+}
+
+// return the source between the given byte offsets
+func (l *Lexer) slice(start, end int) string {
+	if end > len(l.source) {
+		end = len(l.source)
+	}
+
+	return l.source[start:end]
 }
 
 // return the next of character of the current string
 func (l *Lexer) peekCharacter() string {
-	if l.read_position >= utf8.RuneCountInString(l.source) {
+	return l.peekNthCharacter(1)
+}
+
+// return the character n positions after the current character
+func (l *Lexer) peekNthCharacter(n int) string {
+	position := l.read_position
+	for ; n > 1; n-- {
+		l.fill(position, utf8.UTFMax)
+		if position >= len(l.source) {
+			return ""
+		}
+
+		_, size := utf8.DecodeRuneInString(l.source[position:])
+		position += size
+	}
+
+	l.fill(position, utf8.UTFMax)
+	if position >= len(l.source) {
 		return ""
 	}
 
-	return string(l.source[l.read_position])
+	_, size := utf8.DecodeRuneInString(l.source[position:])
+	return l.source[position : position+size]
 }
 
 // skip all whitespaces
 func (l *Lexer) skipWhiteSpaces() {
-	for wSpaceRegex.MatchString(l.character) {
+	for isWhiteSpace(l.character) {
 		l.readCharacter()
 	}
 }
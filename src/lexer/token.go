@@ -70,6 +70,10 @@ const (
 	CONTINUE
 	BREAK
 	QUESTION
+	SET
+	AMPERSAND
+	CONST
+	FSTRING
 )
 
 // String representation of all tokens
@@ -116,26 +120,48 @@ var Tokens = [...]string{
 	TIMES:       "*",
 	STRING:      `"`,
 	THROW:       "lanzar",
-	TRUE:        "verdaro",
+	TRUE:        "verdadero",
 	WHILE:       "mientras",
 	NULLT:       "nulo",
 	MAP:         "mapa",
 	PLUSASSING:  "+=",
 	MINUSASSING: "-=",
-	TRY:         "intenta",
+	TRY:         "intentar",
 	TIMEASSI:    "*=",
 	DIVASSING:   "/=",
 	EXPONENT:    "**",
 	IMPORT:      "importar",
-	CONTINUE:    "continuear",
+	CONTINUE:    "continuar",
 	BREAK:       "romper",
 	QUESTION:    "?",
+	SET:         "conjunto",
+	AMPERSAND:   "&",
+	CONST:       "const",
+	FSTRING:     `f"`,
+	DATASTRCUT:  "lista",
+	FOR:         "por",
+	GTOREQ:      ">=",
+	LTOREQ:      "<=",
+	MINUS2:      "--",
+	NEW:         "nuevo",
+	PLUS2:       "++",
 }
 
 // Represents a Token in the programmig lenguage
 type Token struct {
-	Token_type TokenType // represents the type of the token
-	Literal    string    // represents the literal of the token
+	Token_type TokenType    // represents the type of the token
+	Literal    string       // represents the literal of the token
+	Parts      []StringPart // represents the parts of an interpolated string
+	Line       int          // represents the line where the token starts
+	Column     int          // represents the column where the token starts
+	Doc        string       // represents the doc comment written before the token
+}
+
+// represents a part of an interpolated string, the expressions are the
+// source code written between braces
+type StringPart struct {
+	Value        string
+	IsExpression bool
 }
 
 // Generate a new Token instance
@@ -148,33 +174,9 @@ func (t *Token) PrintToken() string {
 	return fmt.Sprintf("Token Type: %s, Literal: %s", Tokens[t.Token_type], t.Literal)
 }
 
-// verify that given literal is a keyword or not
+// verify that given literal is a keyword of the default language or not
 func LookUpTokenType(literal string) TokenType {
-	keywords := map[string]TokenType{
-		"falso":     FALSE,
-		"funcion":   FUNCTION,
-		"regresa":   RETURN,
-		"si":        IF,
-		"si_no":     ELSE,
-		"var":       LET,
-		"verdadero": TRUE,
-		"en":        IN,
-		"mientras":  WHILE,
-		"por":       FOR,
-		"lista":     DATASTRCUT,
-		"nulo":      NULLT,
-		"mapa":      MAP,
-		"clase":     CLASS,
-		"nuevo":     NEW,
-		"importar":  IMPORT,
-		"intentar":  TRY,
-		"excepto":   EXCEPT,
-		"lanzar":    THROW,
-		"continuar": CONTINUE,
-		"romper":    BREAK,
-	}
-
-	if TokenType, exists := keywords[literal]; exists {
+	if TokenType, exists := Languages[defaultLanguage][literal]; exists {
 		return TokenType
 	}
 
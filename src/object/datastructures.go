@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type applyFunc func(Object, ...Object) Object
@@ -13,6 +15,7 @@ type isTruthyFunc func(Object) bool
 // Represents an Array
 type List struct {
 	Values []Object // represents all the values in the array
+	Frozen bool     // represents if the list can not be modified
 }
 
 func (l *List) Type() ObjectType { return LIST }
@@ -58,7 +61,7 @@ func (l *List) RemoveAt(index int) Object {
 
 func (l *List) Contains(obj Object) Object {
 	for _, val := range l.Values {
-		if reflect.DeepEqual(val, obj) {
+		if Equals(val, obj) {
 			return SingletonTRUE
 		}
 	}
@@ -107,16 +110,181 @@ func (l *List) Count(fn *Def, applyFunction applyFunc, isTruthy isTruthyFunc) *N
 	return count
 }
 
+// return a new list with the values in reverse order
+func (l *List) Reverse() *List {
+	reversed := &List{Values: make([]Object, len(l.Values))}
+	for idx, val := range l.Values {
+		reversed.Values[len(l.Values)-1-idx] = val
+	}
+
+	return reversed
+}
+
+// insert the value before the given index, a negative index is counted from the end
+func (l *List) Insert(index int, obj Object) Object {
+	if index < 0 {
+		index += len(l.Values)
+	}
+
+	if index < 0 || index > len(l.Values) {
+		return &Error{"Indice fuera de rango"}
+	}
+
+	l.Values = append(l.Values, nil)
+	copy(l.Values[index+1:], l.Values[index:])
+	l.Values[index] = obj
+	return SingletonNUll
+}
+
+// add all the values of the other list at the end
+func (l *List) Extend(other *List) {
+	l.Values = append(l.Values, other.Values...)
+}
+
+// return the index of the first ocurrence of the value or -1 if not exists
+func (l *List) Index(obj Object) *Number {
+	for idx, val := range l.Values {
+		if Equals(val, obj) {
+			return &Number{Value: idx}
+		}
+	}
+
+	return &Number{Value: -1}
+}
+
+// return a new list without the repeated values, the values keep the
+// order of its first ocurrence
+func (l *List) Unique() *List {
+	unique := new(List)
+	seen := map[string]bool{}
+	for _, val := range l.Values {
+		key := HashKey(val)
+		if !seen[key] {
+			seen[key] = true
+			unique.Values = append(unique.Values, val)
+		}
+	}
+
+	return unique
+}
+
+// return a list of lists where the list at index i contains the values at
+// index i of this list and the others, the result is as long as the shortest list
+func (l *List) Zip(others []*List) *List {
+	length := len(l.Values)
+	for _, other := range others {
+		if len(other.Values) < length {
+			length = len(other.Values)
+		}
+	}
+
+	zipped := &List{Values: make([]Object, 0, length)}
+	for idx := 0; idx < length; idx++ {
+		group := &List{Values: []Object{l.Values[idx]}}
+		for _, other := range others {
+			group.Values = append(group.Values, other.Values[idx])
+		}
+
+		zipped.Values = append(zipped.Values, group)
+	}
+
+	return zipped
+}
+
+// return a list of pairs with the index and the value, the index starts
+// at the given number
+func (l *List) Enumerate(start int) *List {
+	enumerated := &List{Values: make([]Object, 0, len(l.Values))}
+	for idx, val := range l.Values {
+		pair := &List{Values: []Object{&Number{Value: start + idx}, val}}
+		enumerated.Values = append(enumerated.Values, pair)
+	}
+
+	return enumerated
+}
+
+// return a new list with the same values
+func (l *List) Copy() *List {
+	return &List{Values: append([]Object{}, l.Values...)}
+}
+
+// return a new list where the nested lists are replaced by its values,
+// a negative depth flattens all the levels
+func (l *List) Flatten(depth int) *List {
+	flat := new(List)
+	for _, val := range l.Values {
+		if nested, isList := val.(*List); isList && depth != 0 {
+			flat.Values = append(flat.Values, nested.Flatten(depth-1).Values...)
+			continue
+		}
+
+		flat.Values = append(flat.Values, val)
+	}
+
+	return flat
+}
+
 // represents a HashMap
 type Map struct {
-	Store map[string]Object // represents the hashmap it self
+	Store  map[string]Object // represents the hashmap it self, keyed by HashKey
+	Keys   []Object          // represents the keys in the order they were added
+	Frozen bool              // represents if the map can not be modified
+}
+
+// return the key used to store the value in a map or a set. the key has the
+// type of the value so values with the same text like 1 and "1" are different
+// keys, the values inside a tuple or a list are hashed the same way
+func HashKey(value Object) string {
+	valueType := value.Type()
+	if valueType == BIGINT {
+		// the big integers and the integers are the same type for the user
+		valueType = INTEGERS
+	}
+
+	var values []Object
+	switch value := value.(type) {
+	case *Decimal, *Fraction:
+		// the exact numbers are hashed by their value, without the scale of
+		// the decimal, so they match the integers and each other like in ==
+		rat, _ := ToRat(value)
+		if rat.IsInt() {
+			valueType = INTEGERS
+		} else {
+			valueType = DECIMAL
+		}
+
+		text := rat.RatString()
+		return fmt.Sprintf("%d:%d:%s", valueType, len(text), text)
+
+	case *Tuple:
+		values = value.Values
+
+	case *List:
+		values = value.Values
+
+	default:
+		text := value.Inspect()
+		return fmt.Sprintf("%d:%d:%s", valueType, len(text), text)
+	}
+
+	keys := make([]string, 0, len(values))
+	for _, val := range values {
+		keys = append(keys, HashKey(val))
+	}
+
+	return fmt.Sprintf("%d:(%s)", valueType, strings.Join(keys, ","))
+}
+
+// generates a new empty map instance
+func NewMap() *Map {
+	return &Map{Store: map[string]Object{}}
 }
 
 func (m *Map) Type() ObjectType { return DICT }
 func (m *Map) Inspect() string {
-	var buff = make([]string, 0, len(m.Store))
-	for key, val := range m.Store {
-		str := fmt.Sprintf("%s => %s", key, val.Inspect())
+	var buff = make([]string, 0, len(m.Keys))
+	for _, key := range m.Keys {
+		str := fmt.Sprintf("%s => %s", key.Inspect(), m.Store[HashKey(key)].Inspect())
 		buff = append(buff, str)
 	}
 
@@ -124,8 +292,8 @@ func (m *Map) Inspect() string {
 }
 
 // get the value associeted with the given key if exists
-func (m *Map) Get(key string) Object {
-	obj, exists := m.Store[key]
+func (m *Map) Get(key Object) Object {
+	obj, exists := m.Store[HashKey(key)]
 	if !exists {
 		return NullVAlue
 	}
@@ -133,21 +301,346 @@ func (m *Map) Get(key string) Object {
 	return obj
 }
 
+// return the values of the map in the order the keys were added
+func (m *Map) Values() []Object {
+	values := make([]Object, 0, len(m.Keys))
+	for _, key := range m.Keys {
+		values = append(values, m.Store[HashKey(key)])
+	}
+
+	return values
+}
+
 // update the value associeted with the given key if exists
 // if not exists is just added to the map
 func (m *Map) UpdateKey(key, newVal Object) {
-	m.Store[key.Inspect()] = newVal
+	hash := HashKey(key)
+	if _, exists := m.Store[hash]; !exists {
+		m.Keys = append(m.Keys, key)
+	}
+
+	m.Store[hash] = newVal
 }
 
 // Set the key value pair in the map and ckeck if the key already exists
 func (m *Map) SetValues(key Object, value Object) error {
-	if _, exists := m.Store[key.Inspect()]; exists {
+	hash := HashKey(key)
+	if _, exists := m.Store[hash]; exists {
 		return errors.New("la llave ya existe en el mapa")
 	}
-	m.Store[key.Inspect()] = value
+
+	m.Keys = append(m.Keys, key)
+	m.Store[hash] = value
 	return nil
 }
 
+// check if the key exists in the map
+func (m *Map) Has(key Object) bool {
+	_, exists := m.Store[HashKey(key)]
+	return exists
+}
+
+// remove the key from the map and return its value if exists
+func (m *Map) Delete(key Object) (Object, bool) {
+	hash := HashKey(key)
+	value, exists := m.Store[hash]
+	if !exists {
+		return nil, false
+	}
+
+	delete(m.Store, hash)
+	for idx, mapKey := range m.Keys {
+		if HashKey(mapKey) == hash {
+			m.Keys = append(m.Keys[:idx], m.Keys[idx+1:]...)
+			break
+		}
+	}
+
+	return value, true
+}
+
+// remove all the keys of the map
+func (m *Map) Clear() {
+	m.Store = map[string]Object{}
+	m.Keys = nil
+}
+
+// return a list of pairs with the keys and the values of the map
+func (m *Map) Pairs() *List {
+	pairs := &List{Values: make([]Object, 0, len(m.Keys))}
+	for _, key := range m.Keys {
+		pairs.Values = append(pairs.Values, &List{Values: []Object{key, m.Store[HashKey(key)]}})
+	}
+
+	return pairs
+}
+
+// add all the key value pairs of the other map, the existing keys are replaced
+func (m *Map) Update(other *Map) {
+	for _, key := range other.Keys {
+		m.UpdateKey(key, other.Store[HashKey(key)])
+	}
+}
+
+// return a new map with the same key value pairs
+func (m *Map) Copy() *Map {
+	copied := NewMap()
+	copied.Update(m)
+	return copied
+}
+
+// represents a set of unique values, the values are stored as the keys
+// of a map so they are compared with the same hash of the map keys
+type Set struct {
+	elements *Map // represents the values in the order they were added
+	Frozen   bool // represents if the set can not be modified
+}
+
+// generates a new set instance with the given values
+func NewSet(values ...Object) *Set {
+	set := &Set{elements: NewMap()}
+	for _, value := range values {
+		set.Add(value)
+	}
+
+	return set
+}
+
+func (s *Set) Type() ObjectType { return SET }
+func (s *Set) Inspect() string {
+	var buff = make([]string, 0, len(s.elements.Keys))
+	for _, value := range s.elements.Keys {
+		buff = append(buff, value.Inspect())
+	}
+
+	return fmt.Sprintf("{%s}", strings.Join(buff, ", "))
+}
+
+// return the values of the set in the order they were added
+func (s *Set) Values() []Object {
+	return append([]Object{}, s.elements.Keys...)
+}
+
+// return the number of values in the set
+func (s *Set) Len() int {
+	return len(s.elements.Keys)
+}
+
+// add the value to the set, return false if the value already exists
+func (s *Set) Add(value Object) bool {
+	if s.Contains(value) {
+		return false
+	}
+
+	s.elements.UpdateKey(value, value)
+	return true
+}
+
+// remove the value from the set, return false if the value not exists
+func (s *Set) Remove(value Object) bool {
+	_, exists := s.elements.Delete(value)
+	return exists
+}
+
+// check if the value exists in the set
+func (s *Set) Contains(value Object) bool {
+	return s.elements.Has(value)
+}
+
+// remove all the values of the set
+func (s *Set) Clear() {
+	s.elements.Clear()
+}
+
+// return a new set with the same values
+func (s *Set) Copy() *Set {
+	return NewSet(s.elements.Keys...)
+}
+
+// return a new set with the values of both sets
+func (s *Set) Union(other *Set) *Set {
+	union := s.Copy()
+	for _, value := range other.elements.Keys {
+		union.Add(value)
+	}
+
+	return union
+}
+
+// return a new set with the values that are in both sets
+func (s *Set) Intersection(other *Set) *Set {
+	intersection := NewSet()
+	for _, value := range s.elements.Keys {
+		if other.Contains(value) {
+			intersection.Add(value)
+		}
+	}
+
+	return intersection
+}
+
+// return a new set with the values that are not in the other set
+func (s *Set) Difference(other *Set) *Set {
+	difference := NewSet()
+	for _, value := range s.elements.Keys {
+		if !other.Contains(value) {
+			difference.Add(value)
+		}
+	}
+
+	return difference
+}
+
+// check if all the values of the set are in the other set
+func (s *Set) IsSubset(other *Set) bool {
+	for _, value := range s.elements.Keys {
+		if !other.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// check if both sets have the same values without caring about the order
+func (s *Set) Equals(other *Set) bool {
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// check if two objects are equal. the maps and the sets are equal when they
+// have the same entries in any order, the lists and the tuples are compared
+// value by value, the decimals and the fractions are compared with the
+// integers by their exact value and the rest of the objects by their value
+func Equals(left, right Object) bool {
+	switch left := left.(type) {
+	case *List:
+		other, isList := right.(*List)
+		return isList && valuesEqual(left.Values, other.Values)
+
+	case *Tuple:
+		other, isTuple := right.(*Tuple)
+		return isTuple && valuesEqual(left.Values, other.Values)
+
+	case *Map:
+		other, isMap := right.(*Map)
+		if !isMap || len(left.Store) != len(other.Store) {
+			return false
+		}
+
+		for key, value := range left.Store {
+			otherValue, exists := other.Store[key]
+			if !exists || !Equals(value, otherValue) {
+				return false
+			}
+		}
+
+		return true
+
+	case *Set:
+		other, isSet := right.(*Set)
+		return isSet && left.Equals(other)
+
+	case *Decimal, *Fraction:
+		return ratEquals(left, right)
+
+	default:
+		if right.Type() == DECIMAL || right.Type() == FRACTION {
+			return ratEquals(left, right)
+		}
+
+		return reflect.DeepEqual(left, right)
+	}
+}
+
+// check if two integers, decimals or fractions have the same value
+func ratEquals(left, right Object) bool {
+	leftRat, isLeftExact := ToRat(left)
+	rightRat, isRightExact := ToRat(right)
+	return isLeftExact && isRightExact && leftRat.Cmp(rightRat) == 0
+}
+
+// check if both slices have equal values in the same order
+func valuesEqual(left, right []Object) bool {
+	if len(left) != len(right) {
+		return false
+	}
+
+	for idx := range left {
+		if !Equals(left[idx], right[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// represents an immutable sequence of values, because it can not be modified
+// it can be used as a map key like:
+//		coordenadas[tupla(1, 2)] = "a"
+type Tuple struct {
+	Values []Object // represents the values in the tuple
+}
+
+// generates a new tuple instance
+func NewTuple(values ...Object) *Tuple {
+	return &Tuple{Values: values}
+}
+
+func (t *Tuple) Type() ObjectType { return TUPLE }
+func (t *Tuple) Inspect() string {
+	var buff = make([]string, 0, len(t.Values))
+	for _, value := range t.Values {
+		buff = append(buff, value.Inspect())
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(buff, ", "))
+}
+
+// freeze the value and all the values inside it so they can not be modified,
+// the values that are already frozen are skipped so the cycles are not a problem
+func Freeze(value Object) {
+	switch value := value.(type) {
+	case *List:
+		if value.Frozen {
+			return
+		}
+
+		value.Frozen = true
+		for _, val := range value.Values {
+			Freeze(val)
+		}
+
+	case *Map:
+		if value.Frozen {
+			return
+		}
+
+		value.Frozen = true
+		for _, key := range value.Keys {
+			Freeze(key)
+			Freeze(value.Store[HashKey(key)])
+		}
+
+	case *Set:
+		if value.Frozen {
+			return
+		}
+
+		value.Frozen = true
+		for _, val := range value.elements.Keys {
+			Freeze(val)
+		}
+
+	case *Tuple:
+		for _, val := range value.Values {
+			Freeze(val)
+		}
+	}
+}
+
+// the maximum length in bytes of a string built by repetir or rellenar
+const MaxStringLength = 1 << 28
+
 // represents the strings object
 type String struct {
 	Value string // represents the value of the string
@@ -191,3 +684,115 @@ func (s *String) Split(sep string) *List {
 	}
 	return splited
 }
+
+// return the index in characters of the first occurrence of the substring
+// or -1 if the string does not contain it
+func (s *String) Find(sub string) *Number {
+	index := strings.Index(s.Value, sub)
+	if index == -1 {
+		return &Number{Value: -1}
+	}
+
+	return &Number{Value: utf8.RuneCountInString(s.Value[:index])}
+}
+
+// return the string repeated the given number of times or an error if the
+// result would be longer than MaxStringLength
+func (s *String) Repeat(count int) Object {
+	if count > 0 && len(s.Value) > MaxStringLength/count {
+		return &Error{Message: "el texto repetido es demasiado largo"}
+	}
+
+	return &String{Value: strings.Repeat(s.Value, count)}
+}
+
+// return a string with the given character added to the left or to the rigth
+// until the string has the given length in characters
+func (s *String) Pad(length int, fill string, left bool) Object {
+	missing := length - utf8.RuneCountInString(s.Value)
+	if missing <= 0 {
+		return &String{Value: s.Value}
+	}
+
+	if len(fill) > (MaxStringLength-len(s.Value))/missing {
+		return &Error{Message: "el texto rellenado es demasiado largo"}
+	}
+
+	padding := strings.Repeat(fill, missing)
+	if left {
+		return &String{Value: padding + s.Value}
+	}
+
+	return &String{Value: s.Value + padding}
+}
+
+// return a new string with the characters in reverse order
+func (s *String) Reverse() *String {
+	runes := []rune(s.Value)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+
+	return &String{Value: string(runes)}
+}
+
+// check if the string is not empty and all the characters satisfy the function
+func (s *String) All(fn func(rune) bool) Object {
+	if s.Value == "" {
+		return SingletonFALSE
+	}
+
+	for _, char := range s.Value {
+		if !fn(char) {
+			return SingletonFALSE
+		}
+	}
+
+	return SingletonTRUE
+}
+
+// check if all the characters are digits
+func (s *String) IsNumber() Object { return s.All(unicode.IsDigit) }
+
+// check if all the characters are letters
+func (s *String) IsLetter() Object { return s.All(unicode.IsLetter) }
+
+// return a list with the characters of the string
+func (s *String) Chars() *List {
+	chars := &List{Values: make([]Object, 0, len(s.Value))}
+	for _, char := range s.Value {
+		chars.Values = append(chars.Values, &String{Value: string(char)})
+	}
+
+	return chars
+}
+
+// return the characters between start and end, the negative indexes are
+// counted from the end and the indexes out of range are limited to the string
+func (s *String) Slice(start, end int) *String {
+	runes := []rune(s.Value)
+	start, end = clampIndex(start, len(runes)), clampIndex(end, len(runes))
+	if start >= end {
+		return &String{Value: ""}
+	}
+
+	return &String{Value: string(runes[start:end])}
+}
+
+// convert a negative index to an index from the start and limit it
+// to the range between 0 and the length
+func clampIndex(index, length int) int {
+	if index < 0 {
+		index += length
+	}
+
+	if index < 0 {
+		return 0
+	}
+
+	if index > length {
+		return length
+	}
+
+	return index
+}
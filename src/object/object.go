@@ -3,7 +3,10 @@ package object
 import (
 	"aura/src/ast"
 	"fmt"
+	"math"
+	"math/big"
 	"strings"
+	"time"
 )
 
 // represents all the types in the programming lenguage
@@ -27,6 +30,17 @@ const (
 	CLASS
 	BREAK
 	CONTINUE
+	MODULE
+	REGEX
+	MATCH
+	DATE
+	DURATION
+	STOPWATCH
+	SET
+	TUPLE
+	BIGINT
+	DECIMAL
+	FRACTION
 )
 
 // represents the methods in the standar library
@@ -49,6 +63,46 @@ const (
 	ISUPPER
 	ISLOWER
 	SPLIT
+	TRIM
+	REPLACE
+	STARTSWITH
+	ENDSWITH
+	FIND
+	REPEAT
+	JOIN
+	PADLEFT
+	PADRIGHT
+	REVERSE
+	ISNUMBER
+	ISLETTER
+	CHARS
+	FORMAT
+	SLICE
+	SORT
+	INSERT
+	EXTEND
+	INDEX
+	UNIQUE
+	REDUCE
+	ZIP
+	ENUMERATE
+	ANY
+	ALL
+	MIN
+	MAX
+	COPY
+	FLATTEN
+	GROUPBY
+	PAIRS
+	DELETE
+	GET
+	MERGE
+	UPDATE
+	HAS
+	CLEAR
+	TRANSFORM
+	SUBSET
+	SUPERSET
 )
 
 // string representation of the types
@@ -65,7 +119,19 @@ var Types = [...]string{
 	LIST:       "lista",
 	METHOD:     "metodo",
 	DICT:       "mapa",
+	FLOATING:   "flotante",
 	CLASS:      "clase",
+	MODULE:     "modulo",
+	REGEX:      "regex",
+	MATCH:      "coincidencia",
+	DATE:       "fecha",
+	DURATION:   "duracion",
+	STOPWATCH:  "cronometro",
+	SET:        "conjunto",
+	TUPLE:      "tupla",
+	BIGINT:     "entero",
+	DECIMAL:    "decimal",
+	FRACTION:   "fraccion",
 }
 
 // Object is an interface for abstract all the structs
@@ -80,6 +146,191 @@ type Number struct{ Value int }
 func (i *Number) Type() ObjectType { return INTEGERS }
 func (i *Number) Inspect() string  { return fmt.Sprint(i.Value) }
 
+// represents an integer that does not fit in an int, the integer
+// operations promote the numbers to big integers when they overflow
+type BigInteger struct{ Value *big.Int }
+
+func (b *BigInteger) Type() ObjectType { return BIGINT }
+func (b *BigInteger) Inspect() string  { return b.Value.String() }
+
+// return a number if the value fits in an int or a big integer if not,
+// this way each integer has only one representation
+func NewInteger(value *big.Int) Object {
+	if value.IsInt64() && value.Int64() >= math.MinInt && value.Int64() <= math.MaxInt {
+		return &Number{Value: int(value.Int64())}
+	}
+
+	return &BigInteger{Value: value}
+}
+
+// return the value of a number or a big integer as a big.Int
+func ToBigInt(object Object) (*big.Int, bool) {
+	switch object := object.(type) {
+	case *Number:
+		return big.NewInt(int64(object.Value)), true
+
+	case *BigInteger:
+		return object.Value, true
+
+	default:
+		return nil, false
+	}
+}
+
+// the maximum number of bits of an integer built by a power, a bigger
+// power would take too long or too much memory to be computed
+const MaxPowerBits = 1 << 24
+
+// check if the base raised to the exponent surely has more bits than
+// MaxPowerBits, the base is at least 2 ** (bitlen - 1) so the result has
+// at least (bitlen - 1) * exponent bits
+func PowerTooLarge(base, exponent *big.Int) bool {
+	if exponent.Sign() <= 0 || base.CmpAbs(big.NewInt(1)) <= 0 {
+		return false
+	}
+
+	if !exponent.IsInt64() || exponent.Int64() > MaxPowerBits {
+		return true
+	}
+
+	return int64(base.BitLen()-1)*exponent.Int64() > MaxPowerBits
+}
+
+// represents an exact decimal number, the scale is the number of digits
+// shown after the decimal point
+type Decimal struct {
+	Value *big.Rat
+	Scale int
+}
+
+func NewDecimal(value *big.Rat, scale int) *Decimal {
+	return &Decimal{Value: value, Scale: scale}
+}
+
+func (d *Decimal) Type() ObjectType { return DECIMAL }
+func (d *Decimal) Inspect() string  { return d.Value.FloatString(d.Scale) }
+
+// parse a decimal like -12.50, the digits after the point are the scale
+func ParseDecimal(str string) (*Decimal, bool) {
+	sign := ""
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+
+	integer, fraction, hasPoint := strings.Cut(str, ".")
+	if integer+fraction == "" || (hasPoint && fraction == "") || !onlyDigits(integer) || !onlyDigits(fraction) {
+		return nil, false
+	}
+
+	value, _ := new(big.Rat).SetString(sign + "0" + integer + "." + fraction + "0")
+	return NewDecimal(value, len(fraction)), true
+}
+
+// check if all the characters in the string are digits
+func onlyDigits(str string) bool {
+	for _, char := range str {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// represents an exact fraction, the value is always normalized
+type Fraction struct{ Value *big.Rat }
+
+func NewFraction(value *big.Rat) *Fraction {
+	return &Fraction{Value: value}
+}
+
+func (f *Fraction) Type() ObjectType { return FRACTION }
+func (f *Fraction) Inspect() string  { return f.Value.RatString() }
+
+// return the exact value of an integer, a decimal or a fraction as a big.Rat
+func ToRat(object Object) (*big.Rat, bool) {
+	switch object := object.(type) {
+	case *Decimal:
+		return object.Value, true
+
+	case *Fraction:
+		return object.Value, true
+
+	default:
+		integer, isInt := ToBigInt(object)
+		if !isInt {
+			return nil, false
+		}
+
+		return new(big.Rat).SetInt(integer), true
+	}
+}
+
+// represents the ways to round a number to a given number of decimals
+type RoundingMode int
+
+const (
+	HALFUP   RoundingMode = iota // the half is rounded away from zero
+	HALFDOWN                     // the half is rounded towards zero
+	HALFEVEN                     // the half is rounded to the even neighbour
+	CEILING
+	FLOOR
+	TRUNCATE
+)
+
+// the names of the rounding modes in the lenguage
+var RoundingModes = map[string]RoundingMode{
+	"mitad_arriba": HALFUP,
+	"mitad_abajo":  HALFDOWN,
+	"mitad_par":    HALFEVEN,
+	"techo":        CEILING,
+	"piso":         FLOOR,
+	"truncar":      TRUNCATE,
+}
+
+// round the value to the given number of decimals using the rounding mode
+func RoundRat(value *big.Rat, decimals int, mode RoundingMode) *big.Rat {
+	// a negative number of decimals rounds to tens, hundreds and so on
+	power := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	factor := new(big.Rat).SetInt(power)
+	if decimals < 0 {
+		power.Exp(big.NewInt(10), big.NewInt(int64(-decimals)), nil)
+		factor.SetFrac(big.NewInt(1), power)
+	}
+
+	scaled := new(big.Rat).Mul(value, factor)
+
+	// the quotient is truncated towards zero
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if remainder.Sign() == 0 {
+		return new(big.Rat).Quo(new(big.Rat).SetInt(quotient), factor)
+	}
+
+	// compare the remainder with the half of the denominator
+	doubled := new(big.Int).Lsh(remainder.Abs(remainder), 1)
+	half := doubled.Cmp(scaled.Denom())
+
+	var awayFromZero bool
+	switch mode {
+	case HALFUP:
+		awayFromZero = half >= 0
+	case HALFDOWN:
+		awayFromZero = half > 0
+	case HALFEVEN:
+		awayFromZero = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+	case CEILING:
+		awayFromZero = scaled.Sign() > 0
+	case FLOOR:
+		awayFromZero = scaled.Sign() < 0
+	}
+
+	if awayFromZero {
+		quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+	}
+
+	return new(big.Rat).Quo(new(big.Rat).SetInt(quotient), factor)
+}
+
 // represents the float object type
 type Float struct{ Value float64 }
 
@@ -133,6 +384,7 @@ type Def struct {
 	Parameters []*ast.Identifier // represents the parameters of the function
 	Body       *ast.Block        // represents the body of the function
 	Env        *Enviroment       // represents the scope of the function
+	Doc        string            // represents the doc comment of the function
 }
 
 // return a new function object instance
@@ -173,8 +425,9 @@ func (b *Builtin) Inspect() string  { return "builtin function" }
 
 // Represents a escope in the programming lengauge
 type Enviroment struct {
-	Store map[string]Object // repesents the store of all variables
-	outer *Enviroment       // represents a posible outer scope
+	Store     map[string]Object // repesents the store of all variables
+	outer     *Enviroment       // represents a posible outer scope
+	constants map[string]bool   // represents the variables that can not be reassigned
 }
 
 // return a new enviroment instance
@@ -204,9 +457,48 @@ func (e *Enviroment) SetItem(key string, val Object) {
 	e.Store[key] = val
 }
 
+// update the variable in the scope where is defined, return false
+// if the variable does not exists
+func (e *Enviroment) Reassign(key string, val Object) bool {
+	if _, exists := e.Store[key]; exists {
+		e.Store[key] = val
+		return true
+	}
+
+	if e.outer != nil {
+		return e.outer.Reassign(key, val)
+	}
+
+	return false
+}
+
+// store an object in the enviroment that can not be reassigned
+func (e *Enviroment) SetConstant(key string, val Object) {
+	if e.constants == nil {
+		e.constants = make(map[string]bool)
+	}
+
+	e.Store[key] = val
+	e.constants[key] = true
+}
+
+// check if the variable is a constant in the scope where is defined
+func (e *Enviroment) IsConstant(key string) bool {
+	if _, exists := e.Store[key]; exists {
+		return e.constants[key]
+	}
+
+	if e.outer != nil {
+		return e.outer.IsConstant(key)
+	}
+
+	return false
+}
+
 // delete an item form the enviroment
 func (e *Enviroment) DelItem(key string) {
 	delete(e.Store, key)
+	delete(e.constants, key)
 }
 
 func (e *Enviroment) SetOuter(env *Enviroment) {
@@ -218,6 +510,9 @@ type Iterator struct {
 	Current Object      // represents the current object
 	List    []Object    // represents the values in the iter
 	Env     *Enviroment // represents the iterator enviroment
+	source  *Generator  // represents the source of the values if the iterator is lazy
+	done    bool        // represents if the lazy source has no more values
+	err     *Error      // represents the error that stopped the lazy source
 }
 
 // return a new iterator instance
@@ -225,9 +520,22 @@ func NewIterator(current Object, values []Object, env *Enviroment) *Iterator {
 	return &Iterator{Current: current, List: values, Env: env}
 }
 
+// return a new iterator instance that request the values to the generator
+// only when they are needed
+func NewLazyIterator(generator *Generator, env *Enviroment) *Iterator {
+	iter := &Iterator{Env: env, source: generator}
+	iter.Current, iter.done = generator.Next()
+	iter.done = !iter.done
+	return iter
+}
+
 // return the next value in the iter if there is any
 // and remove the value from the iter
 func (i *Iterator) Next() Object {
+	if i.source != nil {
+		return i.nextFromSource()
+	}
+
 	if len(i.List) == 0 {
 		return nil
 	}
@@ -241,6 +549,41 @@ func (i *Iterator) Next() Object {
 	return val
 }
 
+// return the current value and request the next one to the lazy source
+func (i *Iterator) nextFromSource() Object {
+	if i.done {
+		return nil
+	}
+
+	val := i.Current
+	if err, isErr := val.(*Error); isErr {
+		// the source failed, the loop stops and returns the error
+		i.done, i.err = true, err
+		return nil
+	}
+
+	if next, hasNext := i.source.Next(); hasNext {
+		i.Current = next
+	} else {
+		i.done = true
+	}
+
+	return val
+}
+
+// return the error that stopped the lazy source or nil if the source
+// ended without errors
+func (i *Iterator) Err() *Error {
+	return i.err
+}
+
+// release the resources of the lazy source when the loop ends early
+func (i *Iterator) Close() {
+	if i.source != nil {
+		i.source.Close()
+	}
+}
+
 func (i *Iterator) Type() ObjectType { return ITER }
 func (i *Iterator) Inspect() string {
 	var buf strings.Builder
@@ -255,6 +598,31 @@ func (i *Iterator) Inspect() string {
 	return fmt.Sprintf("[%s]", buf.String())
 }
 
+// represents a lazy sequence of values, the values are generated
+// one by one when the sequence is iterated
+type Generator struct {
+	Name    string                // represents a description of the values
+	Next    func() (Object, bool) // return the next value and false when there are no more values, an error stops the loop
+	Release func()                // releases the resources of the generator, can be nil
+}
+
+// generates a new generator instance
+func NewGenerator(name string, next func() (Object, bool)) *Generator {
+	return &Generator{Name: name, Next: next}
+}
+
+// release the resources of the generator when it is not iterated until the end
+func (g *Generator) Close() {
+	if g.Release != nil {
+		g.Release()
+	}
+}
+
+func (g *Generator) Type() ObjectType { return ITER }
+func (g *Generator) Inspect() string {
+	return fmt.Sprintf("iterador %s", g.Name)
+}
+
 // represents a method object
 type Method struct {
 	Value      Object       // represents the value evaluated from the arguments
@@ -276,6 +644,7 @@ type Class struct {
 	Name    *ast.Identifier       // represents the class name
 	Params  []*ast.Identifier     // represents the constructor params
 	Methods []*ast.ClassMethodExp // represents all the methods in the class
+	Doc     string                // represents the doc comment of the class
 }
 
 func (c *Class) Type() ObjectType { return CLASS }
@@ -324,6 +693,132 @@ func (c *ClassInstance) Inspect() string {
 	return fmt.Sprintf("clase %s", c.Name)
 }
 
+// represents a standard library module
+type Module struct {
+	Name string      // represents the module name
+	Env  *Enviroment // represents the scope with all the module members
+}
+
+// generates a new module instance with the given members
+func NewModule(name string, members map[string]Object) *Module {
+	return &Module{Name: name, Env: newMembersEnviroment(members)}
+}
+
+func (m *Module) Type() ObjectType { return MODULE }
+func (m *Module) Inspect() string {
+	return fmt.Sprintf("modulo %s", m.Name)
+}
+
+// return the member of the module with the given name if exists
+func (m *Module) Member(name string) (Object, bool) { return m.Env.GetItem(name) }
+
+// represents the objects that expose members with the dot operator like:
+//		modulo.miembro
+type Namespace interface {
+	Object
+	Member(name string) (Object, bool) // return the member with the given name if exists
+}
+
+// represents a compiled regular expression
+type Regex struct {
+	Pattern string      // represents the pattern used to compile the regex
+	Env     *Enviroment // represents the scope with the regex methods
+}
+
+// generates a new regex instance with the given methods
+func NewRegex(pattern string, methods map[string]Object) *Regex {
+	return &Regex{Pattern: pattern, Env: newMembersEnviroment(methods)}
+}
+
+func (r *Regex) Type() ObjectType { return REGEX }
+func (r *Regex) Inspect() string {
+	return fmt.Sprintf("regex %s", r.Pattern)
+}
+
+// return the method of the regex with the given name if exists
+func (r *Regex) Member(name string) (Object, bool) { return r.Env.GetItem(name) }
+
+// represents a match of a regex in a text
+type Match struct {
+	Text string      // represents the text matched
+	Env  *Enviroment // represents the scope with the groups and positions of the match
+}
+
+// generates a new match instance with the given members
+func NewMatch(text string, members map[string]Object) *Match {
+	return &Match{Text: text, Env: newMembersEnviroment(members)}
+}
+
+func (m *Match) Type() ObjectType { return MATCH }
+func (m *Match) Inspect() string {
+	return fmt.Sprintf("coincidencia %s", m.Text)
+}
+
+// return the member of the match with the given name if exists
+func (m *Match) Member(name string) (Object, bool) { return m.Env.GetItem(name) }
+
+// represents a date with time and time zone
+type Date struct {
+	Value time.Time   // represents the date it self
+	Env   *Enviroment // represents the scope with the fields and methods of the date
+}
+
+// generates a new date instance with the given members
+func NewDate(value time.Time, members map[string]Object) *Date {
+	return &Date{Value: value, Env: newMembersEnviroment(members)}
+}
+
+func (d *Date) Type() ObjectType { return DATE }
+func (d *Date) Inspect() string {
+	return d.Value.Format("2006-01-02 15:04:05 MST")
+}
+
+// return the field or method of the date with the given name if exists
+func (d *Date) Member(name string) (Object, bool) { return d.Env.GetItem(name) }
+
+// represents the time elapsed between two dates
+type Duration struct {
+	Value time.Duration // represents the duration it self
+	Env   *Enviroment   // represents the scope with the fields of the duration
+}
+
+// generates a new duration instance with the given members
+func NewDuration(value time.Duration, members map[string]Object) *Duration {
+	return &Duration{Value: value, Env: newMembersEnviroment(members)}
+}
+
+func (d *Duration) Type() ObjectType { return DURATION }
+func (d *Duration) Inspect() string  { return d.Value.String() }
+
+// return the field of the duration with the given name if exists
+func (d *Duration) Member(name string) (Object, bool) { return d.Env.GetItem(name) }
+
+// represents a monotonic clock to measure the elapsed time
+type Stopwatch struct {
+	Env *Enviroment // represents the scope with the stopwatch methods
+}
+
+// generates a new stopwatch instance with the given methods
+func NewStopwatch(methods map[string]Object) *Stopwatch {
+	return &Stopwatch{Env: newMembersEnviroment(methods)}
+}
+
+func (s *Stopwatch) Type() ObjectType { return STOPWATCH }
+func (s *Stopwatch) Inspect() string  { return "cronometro" }
+
+// return the method of the stopwatch with the given name if exists
+func (s *Stopwatch) Member(name string) (Object, bool) { return s.Env.GetItem(name) }
+
+// generates an enviroment with the given members
+func newMembersEnviroment(members map[string]Object) *Enviroment {
+	env := NewEnviroment(nil)
+	for key, member := range members {
+		env.SetItem(key, member)
+	}
+
+	return env
+}
+
 type BreakObj struct{}
 
 func (b *BreakObj) Type() ObjectType { return BREAK }
@@ -41,8 +41,21 @@ func (p *Parser) parseCall(function ast.Expression) ast.Expression {
 func (p *Parser) parseCallList(valueList ast.Expression) ast.Expression {
 	p.checkCurrentTokenIsNotNil()
 	token := p.currentToken
+	defer p.setInSlice(p.setInSlice(true))
+
 	p.advanceTokens()
-	index := p.parseExpression(LOWEST)
+	var index ast.Expression
+	if p.currentToken.Token_type == l.COLON {
+		// the slice has no start -> lista[:2]
+		index = p.parseSlice(token, nil)
+	} else {
+		index = p.parseExpression(LOWEST)
+		if p.peekToken.Token_type == l.COLON {
+			p.advanceTokens()
+			index = p.parseSlice(token, index)
+		}
+	}
+
 	if !p.expepectedToken(l.RBRACKET) {
 		// syntax error. we dont allow tihs -> lista[2,3,4,5;
 		return nil
@@ -51,6 +64,26 @@ func (p *Parser) parseCallList(valueList ast.Expression) ast.Expression {
 	return ast.NewCallList(token, valueList, index)
 }
 
+// parse the end and the step of a slice, the current token is
+// the colon after the start
+func (p *Parser) parseSlice(token *l.Token, start ast.Expression) ast.Expression {
+	slice := ast.NewSlice(token, start, nil, nil)
+	if p.peekToken.Token_type != l.COLON && p.peekToken.Token_type != l.RBRACKET {
+		p.advanceTokens()
+		slice.End = p.parseExpression(LOWEST)
+	}
+
+	if p.peekToken.Token_type == l.COLON {
+		p.advanceTokens()
+		if p.peekToken.Token_type != l.RBRACKET {
+			p.advanceTokens()
+			slice.Step = p.parseExpression(LOWEST)
+		}
+	}
+
+	return slice
+}
+
 // parse a ressigment expression
 func (p *Parser) parseReassigment(ident ast.Expression) ast.Expression {
 	p.checkCurrentTokenIsNotNil()
@@ -83,6 +116,22 @@ func (p *Parser) parseRangeExpression() ast.Expression {
 		return nil
 	}
 	variable := p.parseIdentifier()
+	if p.peekToken.Token_type == l.COMMA {
+		// several variables that receive the values of each item -> por(k, v en pares)
+		variables := []*ast.Identifier{variable.(*ast.Identifier)}
+		for p.peekToken.Token_type == l.COMMA {
+			p.advanceTokens()
+			if !p.expepectedToken(l.IDENT) {
+				// syntax error -> por(k, en pares)
+				return nil
+			}
+
+			variables = append(variables, p.parseIdentifier().(*ast.Identifier))
+		}
+
+		variable = ast.NewDestructuring(token, variables...)
+	}
+
 	if !p.expepectedToken(l.IN) {
 		// syntax error. we dont allow this -> por(i rango(10))
 		return nil
@@ -99,7 +148,10 @@ func (p *Parser) parseClassFieldsCall(left ast.Expression) ast.Expression {
 	token := p.currentToken
 	p.checkPeekTokenIsNotNil()
 	p.advanceTokens()
-	field := p.parseExpression(LOWEST)
+
+	// we use the prefix precedence so only calls and indexes are part of the field
+	// and the rest of the expression is applied to the field call
+	field := p.parseExpression(PREFIX)
 	return ast.NewClassFieldCall(token, left, field)
 }
 
@@ -114,6 +166,7 @@ func (p *Parser) parseAssigmentExp(left ast.Expression) ast.Expression {
 	token := p.currentToken
 	p.advanceTokens()
 	val := p.parseExpression(LOWEST)
+	attachDoc(val, ident.Token.Doc)
 	return ast.NewAssigmentExp(token, ident, val)
 }
 
@@ -153,3 +206,28 @@ func (p *Parser) parseTernaryIf(condition ast.Expression) ast.Expression {
 	alternative := p.parseExpression(PREFIX)
 	return ast.NewTernaryIf(token, condition, consequence, alternative)
 }
+
+// parse the loop and the optional condition of a comprehension after its first value like:
+//		lista[x * 2 por x en datos si x > 0]
+func (p *Parser) parseComprehension(token *l.Token, value ast.Expression, delimiter l.TokenType) ast.Expression {
+	p.advanceTokens()
+	rangeExp, isRange := p.parseRangeExpression().(*ast.RangeExpression)
+	if !isRange {
+		// syntax error -> lista[x por en datos]
+		return nil
+	}
+
+	var condition ast.Expression
+	if p.peekToken.Token_type == l.IF {
+		p.advanceTokens()
+		p.advanceTokens()
+		condition = p.parseExpression(LOWEST)
+	}
+
+	if !p.expepectedToken(delimiter) {
+		// syntax error -> lista[x por x en datos
+		return nil
+	}
+
+	return ast.NewComprehension(token, value, rangeExp, condition)
+}
@@ -62,6 +62,8 @@ var precedences = map[l.TokenType]Precedence{
 	l.DOT:         PREFIX,
 	l.COLONASSING: PREFIX,
 	l.QUESTION:    PRODUCT,
+	l.BAR:         SUM,
+	l.AMPERSAND:   SUM,
 }
 
 // Represents the Parser of the programming lenguage
@@ -74,6 +76,7 @@ type Parser struct {
 	prefixParsFns  PrefixParsFns  // represents all the functions to parse prefix expressions
 	infixParseFns  InfixParseFns  // represents all the functions to parse infix expressions
 	suffixParseFns SuffixParseFns // represents all the functions to parse suffix expressions
+	inSlice        bool           // represents if a colon ends the expression because is part of a slice
 }
 
 // generates a new parser instance
@@ -130,9 +133,11 @@ func (p *Parser) currentPrecedence() Precedence {
 	return precedence
 }
 
-// return the error list in the parser
+// return the errors found by the lexer followed by the errors in the parser
 func (p *Parser) Errors() []string {
-	return p.errors
+	errors := make([]string, 0, len(p.lexer.Errors())+len(p.errors))
+	errors = append(errors, p.lexer.Errors()...)
+	return append(errors, p.errors...)
 }
 
 // parse all the program
@@ -166,12 +171,18 @@ func (p *Parser) expectedTokenError(tokenType l.TokenType) {
 	p.checkCurrentTokenIsNotNil()
 	err := fmt.Sprintf(
 		"se esperaba que el siguient token fuera %s pero se obtuvo %s",
-		l.Tokens[tokenType],
-		l.Tokens[p.peekToken.Token_type],
+		p.lexer.TokenName(tokenType),
+		p.lexer.TokenName(p.peekToken.Token_type),
 	)
 	p.errors = append(p.errors, err)
 }
 
+// add an error with the position of the token where it was found
+func (p *Parser) syntaxError(token *l.Token, message string) {
+	err := fmt.Sprintf("%s en la linea %d, columna %d", message, token.Line, token.Column)
+	p.errors = append(p.errors, err)
+}
+
 // parseBlock will parse a block expression
 func (p *Parser) parseBlock() *ast.Block {
 	p.checkCurrentTokenIsNotNil()
@@ -195,6 +206,7 @@ func (p *Parser) parseBlock() *ast.Block {
 // array values or values in a function call.
 func (p *Parser) parseExpressions(delimiter l.TokenType) []ast.Expression {
 	p.checkCurrentTokenIsNotNil()
+	defer p.setInSlice(p.setInSlice(false))
 	var values []ast.Expression
 	if p.peekToken.Token_type == delimiter {
 		p.advanceTokens()
@@ -240,8 +252,13 @@ func (p *Parser) parseExpression(precedence Precedence) ast.Expression {
 	// we check if there is any suffix expression to be parsed
 	if suffixFn, exists := p.suffixParseFns[p.peekToken.Token_type]; exists {
 		p.advanceTokens()
-		leftExpression = suffixFn(leftExpression)
-		p.advanceTokens()
+		if p.currentToken.Token_type == l.EXPONENT && p.startsExpression(p.peekToken) {
+			// is the power operator like 2 ** 10 and not the suffix like x**
+			leftExpression = p.parseInfixExpression(leftExpression)
+		} else {
+			leftExpression = suffixFn(leftExpression)
+			p.advanceTokens()
+		}
 	}
 
 	// we loop until the precedence is lowest than the next precedence
@@ -263,6 +280,12 @@ func (p *Parser) parseExpression(precedence Precedence) ast.Expression {
 	return leftExpression
 }
 
+// check if there is a function to parse the token as the start of an expression
+func (p *Parser) startsExpression(token *l.Token) bool {
+	_, exists := p.prefixParsFns[token.Token_type]
+	return exists
+}
+
 // parse a class statement
 func (p *Parser) parseClassStatement() ast.Stmt {
 	p.checkCurrentTokenIsNotNil()
@@ -291,7 +314,9 @@ func (p *Parser) parseClassStatement() ast.Stmt {
 		}
 	}
 
-	return ast.NewClassStatement(token, name, params, methods)
+	class := ast.NewClassStatement(token, name, params, methods)
+	class.Doc = token.Doc
+	return class
 }
 
 // parse a expression statement
@@ -340,9 +365,28 @@ func (p *Parser) parseLetSatement() ast.Stmt {
 		p.advanceTokens()
 	}
 
+	attachDoc(value, token.Doc)
 	return ast.NewLetStatement(token, name, value)
 }
 
+// attach the doc comment written before an assignment to the function
+// assigned, the doc comment written before the function itself is kept
+//		/// Dobla un numero.
+//		doble := |x| => x * 2;
+func attachDoc(value ast.Expression, doc string) {
+	switch function := value.(type) {
+	case *ast.Function:
+		if function.Doc == "" {
+			function.Doc = doc
+		}
+
+	case *ast.ArrowFunc:
+		if function.Doc == "" {
+			function.Doc = doc
+		}
+	}
+}
+
 // parse an array of identifiers. this function will be use be use to parse params
 // of a function or class constructors
 func (p *Parser) parseIdentifiers(delimiter l.TokenType) []*ast.Identifier {
@@ -409,7 +453,7 @@ func (p *Parser) parseReturnStatement() ast.Stmt {
 func (p *Parser) parseStament() ast.Stmt {
 	p.checkCurrentTokenIsNotNil()
 	switch p.currentToken.Token_type {
-	case l.LET:
+	case l.LET, l.CONST:
 		return p.parseLetSatement()
 
 	case l.RETURN:
@@ -435,6 +479,11 @@ func (p *Parser) parseStament() ast.Stmt {
 // return the precedence of the next token
 func (p *Parser) peekPrecedence() Precedence {
 	p.checkPeekTokenIsNotNil()
+	if p.inSlice && p.peekToken.Token_type == l.COLON {
+		// the colon separates the parts of the slice, is not a method call
+		return LOWEST
+	}
+
 	precedence, exists := precedences[p.peekToken.Token_type]
 	if !exists {
 		return LOWEST
@@ -443,6 +492,13 @@ func (p *Parser) peekPrecedence() Precedence {
 	return precedence
 }
 
+// set if a colon ends the expressions and return the previous value
+func (p *Parser) setInSlice(inSlice bool) bool {
+	previous := p.inSlice
+	p.inSlice = inSlice
+	return previous
+}
+
 // register all the functions to parse infix expressions
 func (p *Parser) registerInfixFns() {
 	p.infixParseFns[l.PLUS] = p.parseInfixExpression
@@ -471,6 +527,8 @@ func (p *Parser) registerInfixFns() {
 	p.infixParseFns[l.DOT] = p.parseClassFieldsCall
 	p.infixParseFns[l.COLONASSING] = p.parseAssigmentExp
 	p.infixParseFns[l.QUESTION] = p.parseTernaryIf
+	p.infixParseFns[l.BAR] = p.parseInfixExpression
+	p.infixParseFns[l.AMPERSAND] = p.parseInfixExpression
 }
 
 // register all the functions to parse prefix expressions
@@ -487,9 +545,11 @@ func (p *Parser) registerPrefixFns() {
 	p.prefixParsFns[l.NOT] = p.parsePrefixExpression
 	p.prefixParsFns[l.TRUE] = p.parseBoolean
 	p.prefixParsFns[l.STRING] = p.parseStringLiteral
+	p.prefixParsFns[l.FSTRING] = p.parseInterpolatedString
 	p.prefixParsFns[l.DATASTRCUT] = p.ParseArray
 	p.prefixParsFns[l.NULLT] = p.ParseNull
 	p.prefixParsFns[l.MAP] = p.parseMap
+	p.prefixParsFns[l.SET] = p.parseSet
 	p.prefixParsFns[l.FLOAT] = p.parseFloat
 	p.prefixParsFns[l.NEW] = p.parseClassCall
 	p.prefixParsFns[l.BAR] = p.parseArrowFunc
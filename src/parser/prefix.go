@@ -3,8 +3,12 @@ package parser
 import (
 	"aura/src/ast"
 	l "aura/src/lexer"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
 )
 
 // parse a boolean expression
@@ -77,7 +81,9 @@ func (p *Parser) parseFunction() ast.Expression {
 		return nil
 	}
 
-	return ast.NewFunction(token, name, body, parameters...)
+	function := ast.NewFunction(token, name, body, parameters...)
+	function.Doc = token.Doc
+	return function
 }
 
 // parse a while expression
@@ -152,34 +158,63 @@ func (p *Parser) parseInteger() ast.Expression {
 	p.checkCurrentTokenIsNotNil()
 	token := p.currentToken
 
-	val, err := strconv.Atoi(p.currentToken.Literal)
-	if err != nil {
-		// the value is not a number. this is very weird to happend
-		message := fmt.Sprintf("no se pudo parsear %s como entero", p.currentToken.Literal)
-		p.errors = append(p.errors, message)
+	// the decimal integers with leading zeros are not octal
+	literal := token.Literal
+	if !hasBasePrefix(literal) {
+		literal = strings.TrimLeft(literal, "0")
+		if literal == "" || literal[0] == '_' {
+			literal = "0" + literal
+		}
+	}
+
+	bigVal, isValid := new(big.Int).SetString(literal, 0)
+	if !isValid {
+		p.syntaxError(token, fmt.Sprintf("literal entero no valido %s", token.Literal))
 		return nil
 	}
 
+	if !bigVal.IsInt64() || bigVal.Int64() > math.MaxInt || bigVal.Int64() < math.MinInt {
+		// the value does not fit in an int so we use a big integer
+		return ast.NewBigInteger(token, bigVal)
+	}
+
+	val := int(bigVal.Int64())
 	return ast.NewInteger(token, &val)
 }
 
+// check if the integer literal starts with 0x, 0b or 0o
+func hasBasePrefix(literal string) bool {
+	return len(literal) > 1 && literal[0] == '0' && strings.ContainsAny(literal[1:2], "xXbBoO")
+}
+
 // parse a float expression
 func (p *Parser) parseFloat() ast.Expression {
 	p.checkCurrentTokenIsNotNil()
 	token := p.currentToken
-	val, err := strconv.ParseFloat(p.currentToken.Literal, 64)
+	val, err := strconv.ParseFloat(token.Literal, 64)
+	if errors.Is(err, strconv.ErrRange) || (err == nil && val == 0 && hasSignificantDigits(token.Literal)) {
+		p.syntaxError(token, fmt.Sprintf("el flotante %s esta fuera de rango", token.Literal))
+		return nil
+	}
+
 	if err != nil {
-		// the value is not a float. this is very weird to happend
-		message := fmt.Sprintf("no se pudo parsear %s como flotante", p.currentToken.Literal)
-		p.errors = append(p.errors, message)
+		p.syntaxError(token, fmt.Sprintf("literal flotante no valido %s", token.Literal))
 		return nil
 	}
 
 	return ast.NewFloatExp(token, val)
 }
 
+// check if the digits before the exponent are not all zeros, a float
+// with significant digits that is parsed as zero is too small
+func hasSignificantDigits(literal string) bool {
+	mantissa, _, _ := strings.Cut(strings.ToLower(literal), "e")
+	return strings.ContainsAny(mantissa, "123456789")
+}
+
 // parse a group expression like (5 + 5) / 2
 func (p *Parser) parseGroupExpression() ast.Expression {
+	defer p.setInSlice(p.setInSlice(false))
 	p.advanceTokens()
 	expression := p.parseExpression(LOWEST)
 	if !p.expepectedToken(l.RPAREN) {
@@ -207,6 +242,42 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	return ast.NewStringLiteral(p.currentToken, p.currentToken.Literal)
 }
 
+// parse an interpolated string, each expression between braces is parsed
+// with its own parser
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	p.checkCurrentTokenIsNotNil()
+	token := p.currentToken
+	parts := make([]ast.Expression, 0, len(token.Parts))
+
+	for _, part := range token.Parts {
+		if !part.IsExpression {
+			parts = append(parts, ast.NewStringLiteral(token, part.Value))
+			continue
+		}
+
+		parser := NewParser(p.lexer.Sublexer(part.Value))
+		program := parser.ParseProgam()
+		for _, err := range parser.Errors() {
+			p.errors = append(p.errors, fmt.Sprintf("en el texto interpolado: %s", err))
+		}
+
+		var statement *ast.ExpressionStament
+		if len(program.Staments) == 1 {
+			statement, _ = program.Staments[0].(*ast.ExpressionStament)
+		}
+
+		if statement == nil || statement.Expression == nil {
+			message := fmt.Sprintf("se esperaba una expresion en el texto interpolado: {%s}", part.Value)
+			p.errors = append(p.errors, message)
+			return nil
+		}
+
+		parts = append(parts, statement.Expression)
+	}
+
+	return ast.NewInterpolatedString(token, parts)
+}
+
 // parse a array expression
 func (p *Parser) ParseArray() ast.Expression {
 	p.checkCurrentTokenIsNotNil()
@@ -215,10 +286,69 @@ func (p *Parser) ParseArray() ast.Expression {
 		// syntax error -> lista 2,3,4,5
 		return nil
 	}
-	values := p.parseExpressions(l.RBRACKET)
+
+	values, comprehension := p.parseCollectionValues(token, l.RBRACKET)
+	if comprehension != nil {
+		return comprehension
+	}
+
 	return ast.NewArray(token, values...)
 }
 
+// parse a set expression
+func (p *Parser) parseSet() ast.Expression {
+	p.checkCurrentTokenIsNotNil()
+	token := p.currentToken
+	if !p.expepectedToken(l.LBRACE) {
+		// syntax error -> conjunto 1, 2, 3
+		return nil
+	}
+
+	values, comprehension := p.parseCollectionValues(token, l.RBRACE)
+	if comprehension != nil {
+		return comprehension
+	}
+
+	return ast.NewSetExpression(token, values...)
+}
+
+// parse the values of a list or a set until the delimiter, if the first value
+// is followed by por the values are a comprehension like:
+//		lista[x * 2 por x en datos]
+func (p *Parser) parseCollectionValues(token *l.Token, delimiter l.TokenType) ([]ast.Expression, ast.Expression) {
+	if p.peekToken.Token_type == delimiter {
+		p.advanceTokens()
+		return nil, nil
+	}
+
+	defer p.setInSlice(p.setInSlice(false))
+	p.advanceTokens()
+	first := p.parseExpression(LOWEST)
+	if p.peekToken.Token_type == l.FOR {
+		return nil, p.parseComprehension(token, first, delimiter)
+	}
+
+	var values []ast.Expression
+	if first != nil {
+		values = append(values, first)
+	}
+
+	for p.peekToken.Token_type == l.COMMA {
+		p.advanceTokens()
+		p.advanceTokens()
+		if expression := p.parseExpression(LOWEST); expression != nil {
+			values = append(values, expression)
+		}
+	}
+
+	if !p.expepectedToken(delimiter) {
+		// syntax error -> lista[1, 2
+		return nil, nil
+	}
+
+	return values, nil
+}
+
 // parse a map expression
 func (p *Parser) parseMap() ast.Expression {
 	p.checkCurrentTokenIsNotNil()
@@ -232,6 +362,10 @@ func (p *Parser) parseMap() ast.Expression {
 	p.advanceTokens()
 	keyVal := p.parseKeyValues()
 	if keyVal != nil {
+		if p.peekToken.Token_type == l.FOR {
+			return p.parseComprehension(token, keyVal, l.RBRACE)
+		}
+
 		keyValues = append(keyValues, keyVal)
 	}
 
@@ -274,7 +408,9 @@ func (p *Parser) parseClassMethod() ast.Expression {
 	}
 
 	p.advanceTokens()
-	return ast.NewClassMethodExp(token, name, params, body)
+	method := ast.NewClassMethodExp(token, name, params, body)
+	method.Doc = token.Doc
+	return method
 }
 
 // parse a call to instanciate a new class
@@ -300,6 +436,12 @@ func (p *Parser) parseImportStatement() ast.Stmt {
 	token := p.currentToken
 	p.advanceTokens()
 	path := p.parseExpression(LOWEST)
+
+	p.checkPeekTokenIsNotNil()
+	if p.peekToken.Token_type == l.SEMICOLON {
+		p.advanceTokens()
+	}
+
 	return ast.NewImportStatement(token, path)
 }
 
@@ -7,6 +7,7 @@ import (
 	p "aura/src/parser"
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
@@ -38,6 +39,18 @@ func clearConsole(writter *bufio.Writer) {
 	}
 }
 
+// return a reader over the lines written in the repl, the lexer streams
+// the lines one after the other instead of joining them in a single string
+// and each line keeps its own line number in the errors
+func historyReader(lines []string) io.Reader {
+	readers := make([]io.Reader, 0, len(lines)*2)
+	for _, line := range lines {
+		readers = append(readers, strings.NewReader(line), strings.NewReader("\n"))
+	}
+
+	return io.MultiReader(readers...)
+}
+
 // Start the repl
 func StartRpl() {
 	scanner := bufio.NewScanner(os.Stdin)
@@ -69,7 +82,7 @@ func StartRpl() {
 		}
 
 		scanned = append(scanned, source)
-		lexer := l.NewLexer(strings.Join(scanned, " "))
+		lexer := l.NewReaderLexer(historyReader(scanned))
 		parser := p.NewParser(lexer)
 
 		env := obj.NewEnviroment(nil)
@@ -1,10 +1,16 @@
 package test
 
 import (
+	b "aura/src/builtins"
 	"aura/src/evaluator"
 	l "aura/src/lexer"
 	obj "aura/src/object"
 	p "aura/src/parser"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -15,6 +21,9 @@ type tuple[T any] struct {
 	expected T
 }
 
+// represents an expected error message in the tests
+type expectedError string
+
 type EvaluatorTests struct {
 	suite.Suite
 }
@@ -369,15 +378,629 @@ func (e *EvaluatorTests) TestMapMethods() {
 		{`m := mapa{"a" => 1, "b" => 2}; m:contiene("a");`, true},
 		{`m := mapa{"a" => 1, "b" => 2}; m:contiene("b");`, true},
 		{`m := mapa{"a" => 1, "b" => 2}; m:contiene("d");`, false},
+		{`m := mapa{1 => "a", "1" => "b"}; largo(m);`, 2},
+		{`m := mapa{1 => "a", "1" => "b"}; m["1"];`, "b"},
+		{`m := mapa{1 => "a", "1" => "b"}; m:eliminar(1); m:contiene("1");`, true},
 		{`m := mapa{"a" => 1, "b" => 2}; m:valores();`, []int{1, 2}},
+		{`m := mapa{"b" => 1, "a" => 2}; m:llaves();`, []string{"b", "a"}},
+		{`m := mapa{"a" => 1, "b" => 2}; m:pares();`, "[[a, 1], [b, 2]]"},
+		{`m := mapa{"a" => 1, "b" => 2}; m:items();`, "[[a, 1], [b, 2]]"},
+		{`m := mapa{"a" => 1, "b" => 2}; m:eliminar("a");`, 1},
+		{`m := mapa{"a" => 1, "b" => 2}; m:eliminar("a"); m;`, "{b => 2}"},
+		{`m := mapa{"a" => 1}; m:eliminar("c");`, expectedError("la llave c no existe en el mapa")},
+		{`m := mapa{"a" => 1}; m:obtener("a", 5);`, 1},
+		{`m := mapa{"a" => 1}; m:obtener("c", 5);`, 5},
+		{`m := mapa{"a" => 1}; x := m:obtener("c"); x == nulo;`, true},
+		{`m := mapa{"a" => 1, "b" => 2}; m:fusionar(mapa{"b" => 3, "c" => 4});`, "{a => 1, b => 3, c => 4}"},
+		{`m := mapa{"a" => 1}; m:fusionar(mapa{"b" => 3}); largo(m);`, 1},
+		{`m := mapa{"a" => 1}; m:actualizar(mapa{"a" => 0, "b" => 3}); m;`, "{a => 0, b => 3}"},
+		{`m := mapa{"a" => nulo}; m:tiene("a");`, true},
+		{`m := mapa{"a" => 1}; m:tiene("b");`, false},
+		{`m := mapa{"a" => 1}; m:limpiar(); largo(m);`, 0},
+		{`m := mapa{"a" => 1}; c := m:copiar(); c["b"] = 2; largo(m);`, 1},
+		{`m := mapa{"a" => 1, "b" => 2}; m:transformar(|k, v| => k + texto(v * 10));`, "{a => a10, b => b20}"},
+		{`m := mapa{"a" => 1, "b" => 2, "c" => 3}; m:filtrar(|k, v| => v > 1);`, "{b => 2, c => 3}"},
+		{`m := mapa{"a" => 1}; m:filtrar(|v| => v > 1);`, expectedError("la funcion para un mapa debe recibir la llave y el valor")},
+		{`m := mapa{"a" => 1, "b" => 2}; largo(m);`, 2},
+		{
+			`m := mapa{"a" => 1, "b" => 2}; r := ""; por(k en m) { r += k; r += texto(m[k]); } r;`,
+			"a1b2",
+		},
+		{`l := lista[1, 2]; l:filtrar(|a, b| => a);`, expectedError("La funcion filtrar solo puede recibir un argumento para una lista")},
+		// the maps are equal without caring about the order of the keys
+		{`mapa{"a" => 1, "b" => 2} == mapa{"b" => 2, "a" => 1};`, true},
+		{`mapa{"a" => 1, "b" => 2} != mapa{"b" => 2, "a" => 1};`, false},
+		{`mapa{"a" => 1, "b" => 2} == mapa{"a" => 1, "b" => 3};`, false},
+		{`mapa{"a" => 1} == mapa{"a" => 1, "b" => 2};`, false},
+		{`mapa{"a" => mapa{"x" => 1, "y" => 2}} == mapa{"a" => mapa{"y" => 2, "x" => 1}};`, true},
+		{`lista[mapa{"a" => 1, "b" => 2}]:contiene(mapa{"b" => 2, "a" => 1});`, true},
+		{`lista[0, mapa{"a" => 1, "b" => 2}]:indice(mapa{"b" => 2, "a" => 1});`, 1},
+		{`lista[mapa{"a" => 1}] == lista[mapa{"a" => 1}];`, true},
+		{`conjunto{1, 2} == conjunto{2, 1};`, true},
+		{`lista[conjunto{1, 2}]:contiene(conjunto{2, 1});`, true},
+		{`mapa{"a" => conjunto{1, 2}} == mapa{"a" => conjunto{2, 1}};`, true},
 	}
 
 	for _, test := range tests {
 		evaluated := e.evaluateTests(test.source)
-		if boolVal, isBool := test.expected.(bool); isBool {
-			e.testBooleanObject(evaluated, boolVal)
-		} else {
-			e.testIntArrayObject(evaluated, test.expected.([]int))
+		switch expected := test.expected.(type) {
+		case bool:
+			e.testBooleanObject(evaluated, expected)
+
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case []int:
+			e.testIntArrayObject(evaluated, expected)
+
+		case []string:
+			e.testStringArrayObject(evaluated, expected)
+
+		case string:
+			e.Equal(expected, evaluated.Inspect())
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestSlices() {
+	tests := []tuple[interface{}]{
+		{`l := lista[1, 2, 3, 4, 5]; l[1:3];`, []int{2, 3}},
+		{`l := lista[1, 2, 3, 4, 5]; l[:2];`, []int{1, 2}},
+		{`l := lista[1, 2, 3, 4, 5]; l[3:];`, []int{4, 5}},
+		{`l := lista[1, 2, 3, 4, 5]; l[:];`, []int{1, 2, 3, 4, 5}},
+		{`l := lista[1, 2, 3, 4, 5]; l[-2:];`, []int{4, 5}},
+		{`l := lista[1, 2, 3, 4, 5]; l[::2];`, []int{1, 3, 5}},
+		{`l := lista[1, 2, 3, 4, 5]; l[1::2];`, []int{2, 4}},
+		{`l := lista[1, 2, 3, 4, 5]; l[::-1];`, []int{5, 4, 3, 2, 1}},
+		{`l := lista[1, 2, 3, 4, 5]; l[3:0:-1];`, []int{4, 3, 2}},
+		{`l := lista[1, 2, 3, 4, 5]; l[3:1];`, []int{}},
+		{`l := lista[1, 2, 3]; i := 1; l[i:i + 1];`, []int{2}},
+		{`l := lista[1, 2, 3]; c := l[:]; c[0] = 9; l[0];`, 1},
+		{`s := "canción"; s[:-1];`, "canció"},
+		{`s := "canción"; s[4:6];`, "ió"},
+		{`s := "hola"; s[::-1];`, "aloh"},
+		{`l := lista[1, 2, 3, 4, 5]; l[1:3] = lista[7, 8, 9]; l;`, []int{1, 7, 8, 9, 4, 5}},
+		{`l := lista[1, 2, 3]; l[:0] = lista[0]; l;`, []int{0, 1, 2, 3}},
+		{`l := lista[1, 2, 3, 4]; l[::2] = lista[0, 0]; l;`, []int{0, 2, 0, 4}},
+		{`l := lista[1, 2, 3, 4]; l[::2] = lista[0]; l;`, expectedError("se esperaban 2 valores para la rebanada, se recibieron 1")},
+		{`l := lista[1, 2, 3]; l[::0];`, expectedError("El paso de una rebanada no puede ser 0")},
+		{`l := lista[1, 2, 3]; l[1:100];`, []int{2, 3}},
+		{`l := lista[1, 2, 3]; l[-100:2];`, []int{1, 2}},
+		{`l := lista[1, 2, 3]; l[5:];`, []int{}},
+		{`l := lista[1, 2, 3]; l[10:-10:-1];`, []int{3, 2, 1}},
+		{`s := "canción"; s[-3:100];`, "ión"},
+		{`s := "canción"; s[-3:100] == s:rebanar(-3, 100);`, true},
+		{`l := lista[1, 2, 3]; l[1:100] = lista[9]; l;`, []int{1, 9}},
+		{`l := lista[1, 2, 3]; l["a":];`, expectedError("los indices de una rebanada deben ser enteros")},
+		{`l := lista[1, 2, 3]; l[1:] = 2;`, expectedError("Solo se puede asignar una lista a una rebanada")},
+		{`m := mapa{"a" => 1}; m[1:];`, expectedError("El objecto mapa no puede ser rebanado")},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case []int:
+			e.testIntArrayObject(evaluated, expected)
+
+		case bool:
+			e.testBooleanObject(evaluated, expected)
+
+		case string:
+			e.testStringObject(evaluated, expected)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestComprehensions() {
+	tests := []tuple[interface{}]{
+		{`datos := lista[1, -2, 3]; lista[x * 2 por x en datos];`, []int{2, -4, 6}},
+		{`datos := lista[1, -2, 3]; lista[x * 2 por x en datos si x > 0];`, []int{2, 6}},
+		{`lista[x por x en lista[] si x > 0];`, []int{}},
+		{`lista[c:mayusculas() por c en "hola"];`, []string{"H", "O", "L", "A"}},
+		{`lista[x + y por x, y en lista[lista[1, 2], lista[3, 4]]];`, []int{3, 7}},
+		{`x := 10; l := lista[x por x en lista[1, 2]]; x;`, 10},
+		{`l := lista[y por y en lista[1, 2]]; y;`, expectedError("Identificador no encontrado: y")},
+		{`pares := lista[lista["a", 1], lista["b", 2]]; mapa{k => v por k, v en pares};`, "{a => 1, b => 2}"},
+		{`m := mapa{"a" => 1, "b" => 2}; mapa{v => k por k, v en m};`, "{1 => a, 2 => b}"},
+		{`m := mapa{"a" => 1, "b" => 2}; mapa{k => m[k] * 10 por k en m si k != "a"};`, "{b => 20}"},
+		{`mapa{x % 2 => x por x en lista[1, 2, 3]};`, "{1 => 3, 0 => 2}"},
+		{`lista[x por x, y en lista[1, 2]];`, expectedError("no se puede desempaquetar 1 en 2 variables")},
+		{`lista[x por x en 5];`, expectedError("No es un iteralble: 5")},
+		{`lista[z por x en lista[1]];`, expectedError("Identificador no encontrado: z")},
+		{
+			`m := mapa{"a" => 1, "b" => 2}; r := ""; por(k, v en m) { r += k; r += texto(v); } r;`,
+			"a1b2",
+		},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case []int:
+			e.testIntArrayObject(evaluated, expected)
+
+		case []string:
+			e.testStringArrayObject(evaluated, expected)
+
+		case string:
+			e.Equal(expected, evaluated.Inspect())
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestSets() {
+	tests := []tuple[interface{}]{
+		{`conjunto{1, 2, 3};`, "{1, 2, 3}"},
+		{`conjunto{1, 2, 2, 3, 1};`, "{1, 2, 3}"},
+		{`conjunto{};`, "{}"},
+		{`largo(conjunto{"a", "b", "a"});`, 2},
+		{`tipo(conjunto{1});`, "conjunto"},
+		{`s := conjunto{1, 2}; s:agregar(3); s:agregar(1); s;`, "{1, 2, 3}"},
+		{`s := conjunto{1, 2}; s:eliminar(1); s;`, "{2}"},
+		{`s := conjunto{1, 2}; s:eliminar(5);`, expectedError("el valor 5 no existe en el conjunto")},
+		{`s := conjunto{1, 2}; s:contiene(2);`, true},
+		{`s := conjunto{1, 2}; s:contiene(5);`, false},
+		{`s := conjunto{1, 2}; s:valores();`, []int{1, 2}},
+		{`s := conjunto{1, 2}; c := s:copiar(); c:agregar(3); largo(s);`, 2},
+		{`s := conjunto{1, 2}; s:limpiar(); largo(s);`, 0},
+		{`conjunto{1, 2} | conjunto{2, 3};`, "{1, 2, 3}"},
+		{`conjunto{1, 2, 3} & conjunto{2, 3, 4};`, "{2, 3}"},
+		{`conjunto{1, 2, 3} - conjunto{2};`, "{1, 3}"},
+		{`conjunto{1} | conjunto{2} & conjunto{2};`, "{2}"},
+		{`conjunto{1, 2} == conjunto{2, 1};`, true},
+		{`conjunto{1, 2} != conjunto{2, 1};`, false},
+		{`conjunto{1} <= conjunto{1, 2};`, true},
+		{`conjunto{1, 2} < conjunto{1, 2};`, false},
+		{`conjunto{1, 2} >= conjunto{2};`, true},
+		{`conjunto{1, 2} > conjunto{3};`, false},
+		{`s := conjunto{1}; s:es_subconjunto(conjunto{1, 2});`, true},
+		{`s := conjunto{1, 3}; s:es_subconjunto(conjunto{1, 2});`, false},
+		{`s := conjunto{1, 2}; s:es_superconjunto(conjunto{2});`, true},
+		{`s := conjunto{1}; s:es_subconjunto(lista[1]);`, expectedError("argumento para es_subconjunto no valido, se recibio lista")},
+		{`conjunto{1} * conjunto{2};`, expectedError("Operador desconocido: conjunto * conjunto")},
+		{`r := 0; por(x en conjunto{1, 2, 2, 3}) { r += x; } r;`, 6},
+		{`conjunto{x % 3 por x en lista[1, 2, 3, 4, 5, 6]};`, "{1, 2, 0}"},
+		{`conjunto{lista[1, 2], lista[1, 2]};`, "{[1, 2]}"},
+		{`largo(conjunto{1, "1", 1.0});`, 3},
+		{`largo(conjunto{lista[1, 2], lista["1", 2], "[1, 2]"});`, 3},
+		{`s := conjunto{1}; s:contiene("1");`, false},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case bool:
+			e.testBooleanObject(evaluated, expected)
+
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case []int:
+			e.testIntArrayObject(evaluated, expected)
+
+		case string:
+			e.Equal(expected, evaluated.Inspect())
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestConstants() {
+	tests := []tuple[interface{}]{
+		{`const a = 5; a;`, 5},
+		{`const a = 5; a = 6;`, expectedError("no se puede reasignar la constante a")},
+		{`const a = 5; a += 1;`, expectedError("no se puede reasignar la constante a")},
+		{`const a = 5; a++;`, expectedError("no se puede reasignar la constante a")},
+		{`const a = 5; a := 6;`, expectedError("no se puede reasignar la constante a")},
+		{`const a = 5; var a = 6;`, expectedError("no se puede reasignar la constante a")},
+		{`const a = 5; f := funcion() { a = 6; }; f();`, expectedError("no se puede reasignar la constante a")},
+		{`const a = 5; f := funcion() { a := 6; regresa a; }; f();`, 6},
+		{`const l = lista[1]; l:agregar(2); largo(l);`, 2},
+		{`var a = 5; a = 6; a;`, 6},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestTuples() {
+	tests := []tuple[interface{}]{
+		{`tupla(1, 2, 3);`, "(1, 2, 3)"},
+		{`tupla();`, "()"},
+		{`tipo(tupla(1));`, "tupla"},
+		{`t := tupla(1, 2, 3); t[1];`, 2},
+		{`t := tupla(1, 2, 3); t[-1];`, 3},
+		{`t := tupla(1, 2, 3); t[1:];`, "(2, 3)"},
+		{`largo(tupla(1, 2));`, 2},
+		{`tupla(1, 2) == tupla(1, 2);`, true},
+		{`tupla(1, 2) == tupla(2, 1);`, false},
+		{`t := tupla(1, 2); t:contiene(2);`, true},
+		{`t := tupla(1, 2); t:indice(2);`, 1},
+		{`r := 0; por(x en tupla(1, 2, 3)) { r += x; } r;`, 6},
+		{`m := mapa{}; m[tupla(1, 2)] = "a"; m[tupla(1, 2)];`, "a"},
+		{`m := mapa{tupla(0, 0) => "origen"}; m[tupla(0, 0)];`, "origen"},
+		{`conjunto{tupla(1, 2), tupla(1, 2)};`, "{(1, 2)}"},
+		{`m := mapa{tupla(1, 2) => "t", "(1, 2)" => "s"}; largo(m);`, 2},
+		{`m := mapa{tupla(1, 2) => "t", "(1, 2)" => "s"}; m[tupla(1, 2)];`, "t"},
+		{`largo(conjunto{tupla(1, "2"), tupla(1, 2)});`, 2},
+		{`t := tupla(1, 2); t[0] = 5;`, expectedError("El objecto tupla no puede ser modificado")},
+		{`t := tupla(1, 2); t:agregar(3);`, expectedError("tupla no tiene un metodo :6(3)")},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case bool:
+			e.testBooleanObject(evaluated, expected)
+
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case string:
+			e.Equal(expected, evaluated.Inspect())
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestFreeze() {
+	tests := []tuple[interface{}]{
+		{`l := congelar(lista[1, 2]); l[0];`, 1},
+		{`l := congelar(lista[1, 2]); l:map(|x| => x * 2);`, "[2, 4]"},
+		{`l := congelar(lista[2, 1]); c := l:copiar(); c:agregar(3); c;`, "[2, 1, 3]"},
+		{`l := congelar(lista[1, 2]); l[0] = 5;`, expectedError("El objecto lista no puede ser modificado")},
+		{`l := congelar(lista[1, 2]); l[0:1] = lista[5];`, expectedError("El objecto lista no puede ser modificado")},
+		{`l := congelar(lista[1, 2]); l:agregar(3);`, expectedError("El objecto lista no puede ser modificado")},
+		{`l := congelar(lista[2, 1]); l:ordenar();`, expectedError("El objecto lista no puede ser modificado")},
+		{`l := congelar(lista[1, 2]); l:pop();`, expectedError("El objecto lista no puede ser modificado")},
+		{`l := congelar(lista[lista[1]]); l[0]:agregar(2);`, expectedError("El objecto lista no puede ser modificado")},
+		{`m := congelar(mapa{"a" => 1}); m["b"] = 2;`, expectedError("El objecto mapa no puede ser modificado")},
+		{`m := congelar(mapa{"a" => 1}); m:eliminar("a");`, expectedError("El objecto mapa no puede ser modificado")},
+		{`m := congelar(mapa{"a" => lista[1]}); l := m["a"]; l:agregar(2);`, expectedError("El objecto lista no puede ser modificado")},
+		{`m := congelar(mapa{"a" => 1}); m:fusionar(mapa{"b" => 2});`, "{a => 1, b => 2}"},
+		{`s := congelar(conjunto{1}); s:agregar(2);`, expectedError("El objecto conjunto no puede ser modificado")},
+		{`t := congelar(tupla(lista[1])); l := t[0]; l:agregar(2);`, expectedError("El objecto lista no puede ser modificado")},
+		{`l := lista[1]; l:agregar(l); congelar(l); largo(l);`, 2},
+		{`congelar(5);`, 5},
+		{`congelar();`, expectedError("numero incorrecto de argumentos para congelar, se recibieron 0, se requieren 1")},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case string:
+			e.Equal(expected, evaluated.Inspect())
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestBigIntegers() {
+	tests := []tuple[interface{}]{
+		{`2 ** 100;`, "1267650600228229401496703205376"},
+		{`2 ** 10;`, 1024},
+		{`9223372036854775807 + 1;`, "9223372036854775808"},
+		{`-9223372036854775807 - 2;`, "-9223372036854775809"},
+		{`0xFF + 0b1 + 0o7;`, 263},
+		{`1_000 * 1_000;`, 1000000},
+		{`1.5e-3 * 2;`, 0.003},
+		{`.5 + 2E1;`, 20.5},
+		{`0x1_0000_0000_0000_0000;`, "18446744073709551616"},
+		{`9223372036854775807 * 2;`, "18446744073709551614"},
+		{`99999999999999999999;`, "99999999999999999999"},
+		{`-9223372036854775808;`, "-9223372036854775808"},
+		{`(2 ** 64) - (2 ** 64) + 5;`, 5},
+		{`(2 ** 64) / (2 ** 60);`, 16},
+		{`(2 ** 64) % 10;`, 6},
+		{`2 ** 64 > 2 ** 63;`, true},
+		{`2 ** 64 == 2 ** 64;`, true},
+		{`2 ** 64 != 5;`, true},
+		{`tipo(2 ** 70);`, "entero"},
+		{`entero("99999999999999999999");`, "99999999999999999999"},
+		{`texto(2 ** 65);`, "36893488147419103232"},
+		{`abs(-(2 ** 65));`, "36893488147419103232"},
+		{`suma(lista[9223372036854775807, 1]);`, "9223372036854775808"},
+		{`(2 ** 64) / 0;`, expectedError("division entre 0 ")},
+		{`(2 ** 64) + 0.5;`, 18446744073709551616.5},
+		{`m := mapa{2 ** 64 => "grande"}; m[2 ** 64];`, "grande"},
+		{`x := 9223372036854775807; x++; x;`, "9223372036854775808"},
+		{`r := 1; por(i en rango(1, 31)) { r *= i; } r;`, "265252859812191058636308480000000"},
+		{`2 ** 10000000000;`, expectedError("el resultado de la potencia es demasiado grande")},
+		{`(2 ** 70) ** (2 ** 70);`, expectedError("el resultado de la potencia es demasiado grande")},
+		{`(-1) ** 10000000000;`, 1},
+		{`largo(texto(2 ** 100000));`, 30103},
+		{`2 ** 0.5;`, 1.4142135623730951},
+		{`4.0 ** 0.5;`, 2.0},
+		{`1.5 ** 2;`, 2.25},
+		{`(2 ** 70) ** 0.5;`, 34359738368.0},
+		{`abs(-9223372036854775807 - 1);`, "9223372036854775808"},
+		{`abs(-9007199254740993);`, 9007199254740993},
+		{`entero(1e20);`, "100000000000000000000"},
+		{`entero(-2.9);`, -2},
+		{`entero(1.0 / 0.0);`, expectedError("entero no puede convertir +Inf en un entero")},
+		{`importar "matematicas"; matematicas.max(lista[1, 2 ** 70]);`, "1180591620717411303424"},
+		{`importar "matematicas"; matematicas.max(2 ** 70, 2 ** 70 + 1);`, "1180591620717411303425"},
+		{`importar "matematicas"; matematicas.min(lista[2.5, -(2 ** 70)]);`, "-1180591620717411303424"},
+		{`importar "matematicas"; matematicas.mcd(2 ** 70, 4);`, 4},
+		{`importar "matematicas"; matematicas.mcd(-12, 18);`, 6},
+		{`importar "matematicas"; matematicas.mcm(2 ** 70, 3);`, "3541774862152233910272"},
+		{`importar "matematicas"; matematicas.raiz(2 ** 70);`, 34359738368.0},
+		{`importar "matematicas"; matematicas.promedio(lista[2 ** 70, 2 ** 70]);`, 1180591620717411303424.0},
+		{
+			`importar "matematicas"; matematicas.redondear(1.5, 2 ** 70);`,
+			expectedError("el entero 1180591620717411303424 esta fuera de rango para redondear"),
+		},
+		{`importar "json"; json.codificar(lista[2 ** 70, 1]);`, "[1180591620717411303424,1]"},
+		{`importar "aleatorio"; x := aleatorio.entero(2 ** 70, 2 ** 70 + 5); x >= 2 ** 70 && x <= 2 ** 70 + 5;`, true},
+		{
+			`importar "aleatorio"; aleatorio.muestra(lista[1], 2 ** 70);`,
+			expectedError("el entero 1180591620717411303424 esta fuera de rango para muestra"),
+		},
+		{`importar "tiempo"; tiempo.segundos(2 ** 70);`, expectedError("la duracion de segundos esta fuera de rango")},
+		{`l := lista[1, 2]; l[2 ** 70];`, expectedError("Indice fuera de rango indice: 1180591620717411303424, longitud: 2")},
+		{`l := lista[1, 2]; l[2 ** 70] = 3;`, expectedError("Indice fuera de rango indice: 1180591620717411303424, longitud: 2")},
+		{`"ab"[-(2 ** 64)];`, expectedError("Indice fuera de rango indice: -18446744073709551616, longitud: 2")},
+		{`l := lista[1, 2]; l["a"];`, expectedError("El indice debe ser un entero")},
+		{`l := lista[1, 2, 3]; l[-(2 ** 70):2 ** 70];`, "[1, 2, 3]"},
+		{`"ab":repetir(2 ** 70);`, expectedError("el entero 1180591620717411303424 esta fuera de rango para repetir")},
+		{`"ab":rellenar_izq(2 ** 70);`, expectedError("el entero 1180591620717411303424 esta fuera de rango para rellenar_izq")},
+		{`l := lista[1]; l:insertar(2 ** 70, 1);`, expectedError("el entero 1180591620717411303424 esta fuera de rango para insertar")},
+		{`rango(2 ** 70);`, expectedError("el entero 1180591620717411303424 esta fuera de rango para rango")},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case float64:
+			e.testFloatObject(evaluated, expected)
+
+		case bool:
+			e.testBooleanObject(evaluated, expected)
+
+		case string:
+			e.Equal(expected, evaluated.Inspect())
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestExactNumbers() {
+	tests := []tuple[interface{}]{
+		{`decimal("0.1") + decimal("0.2");`, "0.3"},
+		{`decimal("0.1") + decimal("0.2") == decimal("0.3");`, true},
+		{`decimal("1.10") * 3;`, "3.30"},
+		{`decimal("1.5") * decimal("1.5");`, "2.25"},
+		{`decimal("10.00") / 4;`, "2.50"},
+		{`decimal("1") / 3;`, "0.3333333333333333333333333333"},
+		{`decimal("7.5") % 2;`, "1.5"},
+		{`decimal("1.1") ** 2;`, "1.21"},
+		{`decimal("2") ** -2;`, "0.25"},
+		{`-decimal("2.50");`, "-2.50"},
+		{`decimal("0.5") + 0.25;`, 0.75},
+		{`decimal("2") == 2;`, true},
+		{`decimal("1.5") > 1;`, true},
+		{`tipo(decimal("1.5"));`, "decimal"},
+		{`d := decimal("1.00"); d += decimal("0.05"); d;`, "1.05"},
+		{`decimal(0.1);`, "0.1"},
+		{`decimal(2 ** 70);`, "1180591620717411303424"},
+		{`fraccion(1, 3) + fraccion(1, 6);`, "1/2"},
+		{`fraccion(1, 3) * 3;`, "1"},
+		{`tipo(fraccion(1, 3) * 3);`, "fraccion"},
+		{`fraccion(2, 3) ** -2;`, "9/4"},
+		{`fraccion(1, 2) + decimal("0.25");`, "3/4"},
+		{`fraccion(1, 3) < decimal("0.34");`, true},
+		{`fraccion(1, 2) * 0.5;`, 0.25},
+		{`fraccion("3/4");`, "3/4"},
+		{`fraccion(0.5);`, "1/2"},
+		{`-fraccion(1, 3);`, "-1/3"},
+		{`entero(decimal("-3.9"));`, -3},
+		{`flotante(fraccion(1, 4));`, 0.25},
+		{`abs(decimal("-1.20"));`, "1.20"},
+		{`texto(decimal("3.10"));`, "3.10"},
+		{`formatear("total: {}", decimal("3.10"));`, "total: 3.10"},
+		{`importar "matematicas"; matematicas.redondear(decimal("2.675"), 2);`, "2.68"},
+		{`importar "matematicas"; matematicas.redondear(decimal("2.665"), 2, "mitad_par");`, "2.66"},
+		{`importar "matematicas"; matematicas.redondear(decimal("-2.5"), 0, "mitad_abajo");`, "-2"},
+		{`importar "matematicas"; matematicas.redondear(decimal("2.61"), 1, "piso");`, "2.6"},
+		{`importar "matematicas"; matematicas.redondear(fraccion(2, 3), 2, "truncar");`, "33/50"},
+		{
+			`importar "matematicas"; matematicas.redondear(1.5, 2, "piso", 4);`,
+			expectedError("numero incorrecto de argumentos para redondear, se recibieron 4, se requieren entre 1 y 3"),
+		},
+		{`importar "matematicas"; matematicas.redondear(2.675, 2);`, 2.68},
+		{`importar "matematicas"; matematicas.piso(decimal("-2.5"));`, -3},
+		{`importar "matematicas"; matematicas.techo(fraccion(7, 2));`, 4},
+		{`importar "matematicas"; matematicas.redondear(decimal("1.25"), 1, "raro");`, expectedError("modo de redondeo desconocido raro")},
+		{`fraccion(1, 0);`, expectedError("el denominador de una fraccion no puede ser 0")},
+		{`fraccion(0.5, 2);`, expectedError("el numerador y el denominador de una fraccion deben ser enteros")},
+		{`decimal("abc");`, expectedError("no se pudo parsear como decimal abc")},
+		{`decimal("1") / 0;`, expectedError("division entre 0 ")},
+		{`fraccion(1, 2) % 0;`, expectedError("Division entre 0")},
+		{`decimal("2") ** decimal("0.5");`, expectedError("el exponente de un decimal o una fraccion debe ser un entero")},
+		{`decimal("2") + "a";`, expectedError("Discrepancia de tipos: decimal + texto")},
+		{`decimal("1.0") ** 1000000000;`, expectedError("el resultado de la potencia es demasiado grande")},
+		{`fraccion(3, 2) ** 100000000;`, expectedError("el resultado de la potencia es demasiado grande")},
+		{`fraccion(3, 2) ** -100000000;`, expectedError("el resultado de la potencia es demasiado grande")},
+		{`lista[decimal("0.10")]:contiene(decimal("0.1"));`, true},
+		{`lista[fraccion(2, 1)]:contiene(2);`, true},
+		{`lista[1, fraccion(1, 2)]:indice(decimal("0.5"));`, 1},
+		{`conjunto{decimal("0.10")}:contiene(decimal("0.1"));`, true},
+		{`largo(conjunto{decimal("0.5"), fraccion(1, 2), decimal("0.50")});`, 1},
+		{`largo(conjunto{fraccion(2, 1), 2, decimal("2.0")});`, 1},
+		{`largo(conjunto{decimal("0.5"), "0.5", 0.5});`, 3},
+		{`m := mapa{decimal("0.10") => 1}; m[decimal("0.1")];`, 1},
+		{`m := mapa{2 => "dos"}; m[fraccion(4, 2)];`, "dos"},
+		{`lista[decimal("0.1")] == lista[decimal("0.10")];`, true},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case float64:
+			e.testFloatObject(evaluated, expected)
+
+		case bool:
+			e.testBooleanObject(evaluated, expected)
+
+		case string:
+			e.Equal(expected, evaluated.Inspect())
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestFormatSpecifiers() {
+	tests := []tuple[interface{}]{
+		{`formatear("{0} y {1} y {0}", "a", "b");`, "a y b y a"},
+		{`formatear("Hola {nombre}, {edad}", mapa{"nombre" => "Ana", "edad" => 20});`, "Hola Ana, 20"},
+		{`formatear("{:.2f}", 3.14159);`, "3.14"},
+		{`formatear("[{:>10}]", "hola");`, "[      hola]"},
+		{`formatear("[{:<6}]", 42);`, "[42    ]"},
+		{`formatear("[{:6}]", 42);`, "[    42]"},
+		{`formatear("[{:*^9}]", "mid");`, "[***mid***]"},
+		{`formatear("{:08d}", -42);`, "-0000042"},
+		{`formatear("{:010.3f}", -3.14159);`, "-00003.142"},
+		{`formatear("{:x} {:X} {:b} {:o}", 255, 255, 5, 8);`, "ff FF 101 10"},
+		{`formatear("{:,}", 1234567);`, "1,234,567"},
+		{`formatear("{:,.2f}", 1234567.891);`, "1,234,567.89"},
+		{`formatear("{:_d}", 2 ** 70);`, "1_180_591_620_717_411_303_424"},
+		{`formatear("{:+d} {: d}", 5, 5);`, "+5  5"},
+		{`formatear("{:.1%}", 0.256);`, "25.6%"},
+		{`formatear("{:.3}", "abcdef");`, "abc"},
+		{`formatear("{:e}", 12345.678);`, "1.234568e+04"},
+		{`formatear("{:.2f}", decimal("2.675"));`, "2.68"},
+		{`formatear("{:.3f}", fraccion(1, 3));`, "0.333"},
+		{`formatear("{{}} {}", 1);`, "{} 1"},
+		{`formatear("sin argumentos");`, "sin argumentos"},
+		{`s := "{:>5}|"; s:formato(7);`, "    7|"},
+		{`formatear("{} {}", 1);`, expectedError("faltan argumentos para formatear, se esperaban al menos 2, se recibieron 1")},
+		{`formatear("{}", 1, 2);`, expectedError("sobran argumentos para formatear, el argumento 1 no se uso")},
+		{`formatear("{} {0}", 1);`, expectedError("no se pueden mezclar campos automaticos {} y numerados {0} en el formato")},
+		{`formatear("{:d}", 1.5);`, expectedError("el formato d no se puede usar con flotante")},
+		{`formatear("{:+}", "a");`, expectedError("el signo y el separador de miles solo se pueden usar con numeros")},
+		{`formatear("{nombre}", 1);`, expectedError("no se encontro el argumento nombre para formatear")},
+		{`formatear("{:q}", 1);`, expectedError("especificador de formato no valido: q")},
+		{`formatear("abre {", 1);`, expectedError("'{' sin cerrar en el formato")},
+		{`formatear("cierra }");`, expectedError("'}' sin pareja en el formato")},
+		{`s := "{} {}"; s:formato(1);`, expectedError("faltan argumentos para formatear, se esperaban al menos 2, se recibieron 1")},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case string:
+			e.testStringObject(evaluated, expected)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestInterpolatedStrings() {
+	tests := []tuple[interface{}]{
+		{`nombre := "Ana"; edad := 20; f"Hola {nombre}, tienes {edad + 1} años";`, "Hola Ana, tienes 21 años"},
+		{`nombre := "ana"; f'{{llaves}} {nombre:mayusculas()}';`, "{llaves} ANA"},
+		{`m := mapa{"a" => 1}; f"valor: {m["a"]}";`, "valor: 1"},
+		{`f"{formatear("{:.2f}", 3.14159)}";`, "3.14"},
+		{`funcion doble(x) { regresa x * 2; } f"{doble(4)} {lista[1, 2]}";`, "8 [1, 2]"},
+		{`x := 1; funcion f() { x := 2; regresa f"{x}"; } f() + f"{x}";`, "21"},
+		{`f"";`, ""},
+		{`f"{noexiste}";`, expectedError("Identificador no encontrado: noexiste")},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case string:
+			e.testStringObject(evaluated, expected)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestHelp() {
+	class := `
+		/// Un punto en el plano.
+		clase Punto(x, y) {
+			/// Distancia al origen al cuadrado.
+			norma() => x * x + y * y;
+			mover(dx) => x + dx;
+		}
+	`
+
+	tests := []tuple[interface{}]{
+		{"/// Dobla un numero.\n/// Regresa el doble.\nfuncion doble(x) => x * 2; ayuda(doble);", "Dobla un numero.\nRegresa el doble."},
+		{"funcion f() => 1; ayuda(f);", "sin documentacion"},
+		{"/// Dobla un numero.\nf := funcion(x) { regresa x * 2; }; ayuda(f);", "Dobla un numero."},
+		{"/// Fibonacci.\nfib := |n| => n; ayuda(fib);", "Fibonacci."},
+		{"/// Triple.\nvar triple = |n| => n * 3; ayuda(triple);", "Triple."},
+		{"/// Cuadrado.\nconst cuadrado = funcion(n) => n * n; ayuda(cuadrado);", "Cuadrado."},
+		{"/// Asignacion.\nf := /// Funcion.\nfuncion(x) => x; ayuda(f);", "Funcion."},
+		{"/// Numero.\nx := 5; g := |n| => n; ayuda(g);", "sin documentacion"},
+		{"ayuda(escribir);", "funcion integrada"},
+		{"/* comentario /* anidado */ */ funcion f() => 1; f(); // sin salto de linea", 1},
+		{class + "p := nuevo Punto(1, 2); ayuda(p.norma);", "Distancia al origen al cuadrado."},
+		{class + "ayuda(Punto);", "clase Punto(x, y)\nUn punto en el plano.\n\nnorma()\nDistancia al origen al cuadrado.\n\nmover(dx)\nsin documentacion"},
+		{"ayuda(1);", expectedError("argumento para ayuda no valido, se recibio entero")},
+		{"ayuda();", expectedError("numero incorrecto de argumentos para ayuda, se recibieron 0, se requieren 1")},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch expected := test.expected.(type) {
+		case string:
+			e.testStringObject(evaluated, expected)
+
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
 		}
 	}
 }
@@ -392,14 +1015,57 @@ func (e *EvaluatorTests) TestStringMethods() {
 		{source: `s := "H"; s:es_mayuscula();`, expected: true},
 		{source: `s := "h"; s:es_minuscula();`, expected: true},
 		{source: `s := "H"; s:es_minuscula();`, expected: false},
+		{source: `s := "  hola  "; s:recortar();`, expected: "hola"},
+		{source: `s := "--hola-"; s:recortar("-");`, expected: "hola"},
+		{source: `s := "a-b-c"; s:reemplazar("-", "+");`, expected: "a+b+c"},
+		{source: `s := "a-b-c"; s:reemplazar("-", "", 1);`, expected: "ab-c"},
+		{source: `s := "mañana"; s:empieza_con("ma");`, expected: true},
+		{source: `s := "mañana"; s:termina_con("ma");`, expected: false},
+		{source: `s := "mañana"; s:buscar("ana");`, expected: 3},
+		{source: `s := "mañana"; s:buscar("x");`, expected: -1},
+		{source: `s := "ja"; s:repetir(3);`, expected: "jajaja"},
+		{source: `s := ", "; s:unir(lista[1, "dos", 3.5]);`, expected: "1, dos, 3.5"},
+		{source: `s := "ñu"; s:rellenar_izq(4);`, expected: "  ñu"},
+		{source: `s := "7"; s:rellenar_izq(3, "0");`, expected: "007"},
+		{source: `s := "ñu"; s:rellenar_der(4, "*");`, expected: "ñu**"},
+		{source: `s := "ñandú"; s:invertir();`, expected: "údnañ"},
+		{source: `s := "0123"; s:es_numero();`, expected: true},
+		{source: `s := "12a"; s:es_numero();`, expected: false},
+		{source: `s := "áéñ"; s:es_letra();`, expected: true},
+		{source: `s := ""; s:es_letra();`, expected: false},
+		{source: `s := "año"; s:caracteres();`, expected: []string{"a", "ñ", "o"}},
+		{source: `s := "{} tiene {} años"; s:formato("ana", 20);`, expected: "ana tiene 20 años"},
+		{source: `s := "canción"; s:rebanar(3);`, expected: "ción"},
+		{source: `s := "canción"; s:rebanar(0, -2);`, expected: "canci"},
+		{source: `s := "canción"; s:rebanar(-3, 100);`, expected: "ión"},
+		{source: `s := "canción"; s[5];`, expected: "ó"},
+		{source: `s := "canción"; s[-1];`, expected: "n"},
+		{source: `s := "ja"; s:repetir(-1);`, expected: expectedError("no se puede repetir un texto un numero negativo de veces")},
+		{source: `s := "a"; s:rellenar_izq(3, "ab");`, expected: expectedError("el relleno para rellenar_izq debe ser un solo caracter")},
+		{source: `s := "ja"; s:repetir(9223372036854775807);`, expected: expectedError("el texto repetido es demasiado largo")},
+		{source: `s := "ja"; s:repetir(200000000);`, expected: expectedError("el texto repetido es demasiado largo")},
+		{source: `s := "a"; s:rellenar_izq(10000000000000);`, expected: expectedError("el texto rellenado es demasiado largo")},
+		{source: `s := "a"; s:rellenar_der(9223372036854775807, "ñ");`, expected: expectedError("el texto rellenado es demasiado largo")},
+		{source: `s := "a"; s:empieza_con(1);`, expected: expectedError("argumento para empieza_con no valido, se recibio entero")},
 	}
 
 	for _, test := range tests {
 		evaluated := e.evaluateTests(test.source)
-		if str, isStr := test.expected.(string); isStr {
-			e.testStringObject(evaluated, str)
-		} else {
-			e.testBooleanObject(evaluated, test.expected.(bool))
+		switch expected := test.expected.(type) {
+		case string:
+			e.testStringObject(evaluated, expected)
+
+		case bool:
+			e.testBooleanObject(evaluated, expected)
+
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case []string:
+			e.testStringArrayObject(evaluated, expected)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
 		}
 	}
 }
@@ -417,14 +1083,76 @@ func (e *EvaluatorTests) TestListMethods() {
 		{"a := lista[2,3,4,12]; a:popIndice(0); a:contiene(2);", false},
 		{"a := lista[2,3,4,2,12]; a:popIndice(0); a:agregar(25); a:contiene(25);", true},
 		{"a := lista[1,2,3]; a = a:map(|x| => { x++; }); a[0];", 2},
+		{`a := lista[]; a:agregar("uno"); a:agregar(lista[2]); a;`, "[uno, [2]]"},
+		{"a := lista[3,1,2]; a:ordenar(); a;", []int{1, 2, 3}},
+		{"a := lista[3,1,2]; a:ordenar(verdadero); a;", []int{3, 2, 1}},
+		{"a := lista[2.5,1,2]; a:ordenar(); a;", "[1, 2, 2.5]"},
+		{`a := lista["bb", "c", "aaa"]; a:ordenar(|x| => largo(x)); a;`, []string{"c", "bb", "aaa"}},
+		{
+			`a := lista[lista[1, "a"], lista[0, "b"], lista[1, "c"], lista[0, "d"]]; a:ordenar(|x| => x[0]); a;`,
+			"[[0, b], [0, d], [1, a], [1, c]]",
+		},
+		{
+			`a := lista[lista[1, "a"], lista[0, "b"], lista[1, "c"]]; a:ordenar(|x| => x[0], verdadero); a;`,
+			"[[1, a], [1, c], [0, b]]",
+		},
+		{`a := lista[1, "a"]; a:ordenar();`, expectedError("no se pueden comparar valores de tipo texto y entero")},
+		{"a := lista[1,2,3]; a:invertir();", []int{3, 2, 1}},
+		{"a := lista[1,3]; a:insertar(1, 2); a;", []int{1, 2, 3}},
+		{"a := lista[1,2]; a:insertar(-1, 5); a;", []int{1, 5, 2}},
+		{"a := lista[1,2]; a:insertar(2, 3); a;", []int{1, 2, 3}},
+		{"a := lista[1,2]; a:insertar(5, 3);", expectedError("Indice fuera de rango")},
+		{"a := lista[1,2]; a:extender(lista[3,4]); a;", []int{1, 2, 3, 4}},
+		{"a := lista[5,6,7]; a:indice(7);", 2},
+		{"a := lista[5,6,7]; a:indice(8);", -1},
+		{`a := lista[1,2,1,"1",3,2]; a:unicos();`, "[1, 2, 1, 3]"},
+		{`a := lista[lista["a, b"], lista["a", "b"], lista["a", "b"]]; largo(a:unicos());`, 2},
+		{"a := lista[1,2,3,4]; a:reducir(|acc, x| => acc + x);", 10},
+		{"a := lista[1,2,3,4]; a:reducir(|acc, x| => acc * x, 10);", 240},
+		{"a := lista[]; a:reducir(|acc, x| => acc + x);", expectedError("no se puede reducir una lista vacia sin un valor inicial")},
+		{`a := lista[1,2,3]; a:zip(lista["a","b"]);`, "[[1, a], [2, b]]"},
+		{`a := lista[1,2]; a:zip(lista["a","b"], lista[verdadero, falso]);`, "[[1, a, verdadero], [2, b, falso]]"},
+		{`a := lista["a","b"]; a:enumerar();`, "[[0, a], [1, b]]"},
+		{`a := lista["a","b"]; a:enumerar(1);`, "[[1, a], [2, b]]"},
+		{"a := lista[1,2,3]; a:algun(|x| => x > 2);", true},
+		{"a := lista[1,2,3]; a:algun(|x| => x > 3);", false},
+		{"a := lista[1,2,3]; a:todos(|x| => x > 0);", true},
+		{"a := lista[1,2,3]; a:todos(|x| => x > 1);", false},
+		{"a := lista[4,1,9,3]; a:min();", 1},
+		{"a := lista[4,1,9,3]; a:max();", 9},
+		{`a := lista["ccc", "a", "bb"]; a:max(|x| => largo(x));`, "ccc"},
+		{"a := lista[]; a:min();", expectedError("no se puede obtener el minimo o maximo de una lista vacia")},
+		{"a := lista[1,2]; b := a:copiar(); b:agregar(3); largo(a);", 2},
+		{"a := lista[1,lista[2,lista[3,lista[4]]]]; a:aplanar();", []int{1, 2, 3, 4}},
+		{"a := lista[1,lista[2,lista[3]]]; a:aplanar(1);", "[1, 2, [3]]"},
+		{
+			`a := lista[1,2,3,4,5]; a:agrupar_por(|x| => x % 2);`,
+			"{1 => [1, 3, 5], 0 => [2, 4]}",
+		},
+		{"a := lista[1]; a:reducir(|x| => x);", expectedError("la funcion para reducir debe recibir 2 argumentos")},
+		{"a := lista[1]; a:algun(5);", expectedError("se requiere una funcion para algun")},
 	}
 
 	for _, test := range tests {
 		evaluated := e.evaluateTests(test.source)
-		if num, isNum := test.expected.(int); isNum {
-			e.testIntegerObject(evaluated, num)
-		} else {
-			e.testBooleanObject(evaluated, test.expected.(bool))
+		switch expected := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, expected)
+
+		case bool:
+			e.testBooleanObject(evaluated, expected)
+
+		case []int:
+			e.testIntArrayObject(evaluated, expected)
+
+		case []string:
+			e.testStringArrayObject(evaluated, expected)
+
+		case string:
+			e.Equal(expected, evaluated.Inspect())
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(expected))
 		}
 	}
 }
@@ -545,6 +1273,14 @@ func (e *EvaluatorTests) TestClassEvaluation() {
 		`,
 			expected: 28,
 		},
+		{source: `
+			clase Persona(name, age) {}
+
+			p := nuevo Persona("joe", 28);
+			p.age + 2;
+		`,
+			expected: 30,
+		},
 	}
 
 	for _, test := range tests {
@@ -693,6 +1429,625 @@ func (e *EvaluatorTests) TestImportStatement() {
 	e.testIntegerObject(evaluated, 5)
 }
 
+func (e *EvaluatorTests) TestMathModule() {
+	tests := []tuple[interface{}]{
+		{source: `importar "matematicas"; matematicas.raiz(16);`, expected: 4.0},
+		{source: `importar "matematicas"; matematicas.raiz(-4);`, expected: "no se puede calcular la raiz de un numero negativo"},
+		{source: `importar "matematicas"; matematicas.potencia(2, 10);`, expected: 1024},
+		{source: `importar "matematicas"; matematicas.potencia(2, -1);`, expected: 0.5},
+		{source: `importar "matematicas"; matematicas.potencia(3, 10000000000);`, expected: "el resultado de la potencia es demasiado grande"},
+		{source: `importar "matematicas"; matematicas.potencia(1, 10000000000);`, expected: 1},
+		{source: `importar "matematicas"; matematicas.piso(2.7);`, expected: 2},
+		{source: `importar "matematicas"; matematicas.techo(2.1);`, expected: 3},
+		{source: `importar "matematicas"; matematicas.redondear(2.5);`, expected: 3},
+		{source: `importar "matematicas"; matematicas.redondear(2.456, 2);`, expected: 2.46},
+		{source: `importar "matematicas"; matematicas.pi * 2 > 6;`, expected: true},
+		{source: `importar "matematicas"; matematicas.cos(0);`, expected: 1.0},
+		{source: `importar "matematicas"; matematicas.asen(2);`, expected: "asen solo acepta valores entre -1 y 1"},
+		{source: `importar "matematicas"; matematicas.log(8, 2);`, expected: 3.0},
+		{source: `importar "matematicas"; matematicas.log10(0);`, expected: "log10 solo acepta numeros mayores a 0"},
+		{source: `importar "matematicas"; matematicas.max(lista[1, 7, 3]);`, expected: 7},
+		{source: `importar "matematicas"; matematicas.min(4, 2.5, 8);`, expected: 2.5},
+		{source: `importar "matematicas"; matematicas.max(lista[]);`, expected: "max necesita al menos un valor"},
+		{source: `importar "matematicas"; matematicas.mcd(12, 18);`, expected: 6},
+		{source: `importar "matematicas"; matematicas.mcm(4, 6);`, expected: 12},
+		{source: `importar "matematicas"; matematicas.mcd(1.5, 2);`, expected: "argumento para mcd no valido, se recibio flotante"},
+		{source: `importar "matematicas"; matematicas.suma(lista[1, 2.5]);`, expected: 3.5},
+		{source: `importar "matematicas"; matematicas.promedio(lista[1, 2]);`, expected: 1.5},
+		{source: `importar "matematicas"; matematicas.raiz("a");`, expected: "argumento para raiz no valido, se recibio texto"},
+		{source: `importar "matematicas"; matematicas.tau;`, expected: "modulo matematicas no tiene el miembro tau"},
+		{source: `matematicas.raiz(4);`, expected: "Identificador no encontrado: matematicas"},
+		{source: `x := 9; importar "matematicas"; matematicas.raiz(x) + 1;`, expected: 4.0},
+		{source: "suma(lista[1.5, 2])", expected: 3.5},
+		{source: `importar "matematicas"; texto(matematicas.potencia(2, 100)) == "1267650600228229401496703205376";`, expected: true},
+		{source: `importar "matematicas"; matematicas.potencia(1, 10000000000);`, expected: 1},
+		{source: `importar "matematicas"; matematicas.potencia(-3, 3);`, expected: -27},
+		{source: `importar "matematicas"; matematicas.potencia(2 ** 64, 2) == 2 ** 128;`, expected: true},
+		{source: `importar "matematicas"; matematicas.piso(1e300) == entero(1e300);`, expected: true},
+		{source: `importar "matematicas"; matematicas.piso(1e300) > 0;`, expected: true},
+		{source: `importar "matematicas"; matematicas.techo(-1e19) == -10000000000000000000;`, expected: true},
+		{source: `importar "matematicas"; matematicas.redondear(9223372036854775807.0) == 2 ** 63;`, expected: true},
+		{source: `importar "matematicas"; matematicas.piso(1e308 * 10);`, expected: "piso no puede convertir +Inf en un entero"},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch val := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, val)
+
+		case float64:
+			e.testFloatObject(evaluated, val)
+
+		case bool:
+			e.testBooleanObject(evaluated, val)
+
+		case string:
+			e.testErrorObject(evaluated, val)
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestRandomModule() {
+	tests := []tuple[interface{}]{
+		{source: `importar "aleatorio"; x := aleatorio.entero(1, 6); x >= 1 && x <= 6;`, expected: true},
+		{source: `importar "aleatorio"; aleatorio.entero(3, 3);`, expected: 3},
+		{source: `importar "aleatorio"; aleatorio.entero(5, 1);`, expected: "el inicio 5 no puede ser mayor al final 1"},
+		{source: `importar "aleatorio"; x := aleatorio.flotante(); x >= 0 && x < 1;`, expected: true},
+		{source: `importar "aleatorio"; lista[4, 5, 6]:contiene(aleatorio.elegir(lista[4, 5, 6]));`, expected: true},
+		{source: `importar "aleatorio"; aleatorio.elegir(lista[]);`, expected: "no se puede elegir un elemento de una lista vacia"},
+		{source: `importar "aleatorio"; x := lista[1, 2, 3, 4]; aleatorio.mezclar(x); largo(x);`, expected: 4},
+		{source: `importar "aleatorio"; aleatorio.mezclar(congelar(lista[1, 2]));`, expected: "El objecto lista no puede ser modificado"},
+		{source: `importar "aleatorio"; largo(aleatorio.muestra(lista[1, 2, 3, 4], 2));`, expected: 2},
+		{source: `importar "aleatorio"; aleatorio.muestra(lista[1, 2], 3);`, expected: "la muestra debe estar entre 0 y 2, se recibio 3"},
+		{source: `importar "aleatorio"; x := aleatorio.entero(0, 9223372036854775807); x >= 0;`, expected: true},
+		{source: `importar "aleatorio"; x := aleatorio.entero(-9223372036854775807 - 1, 9223372036854775807); tipo(x) == "entero";`, expected: true},
+		{source: `importar "aleatorio"; x := aleatorio.entero(-5, 9223372036854775807); x >= -5;`, expected: true},
+		{
+			// every import has its own source, so the seed of one does not affect the other
+			source: `
+			importar "aleatorio";
+			a := aleatorio;
+			importar "aleatorio";
+			b := aleatorio;
+			a.semilla(7);
+			b.semilla(7);
+			x := a.entero(1, 1000000);
+			b.semilla(3);
+			y := a.entero(1, 1000000);
+			a.semilla(7);
+			b.semilla(7);
+			x == a.entero(1, 1000000) && y == a.entero(1, 1000000) && x == b.entero(1, 1000000);
+			`,
+			expected: true,
+		},
+		{
+			source: `
+			importar "aleatorio";
+			aleatorio.semilla(42);
+			a := aleatorio.entero(1, 1000000);
+			aleatorio.semilla(42);
+			a == aleatorio.entero(1, 1000000);
+			`,
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		switch val := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, val)
+
+		case bool:
+			e.testBooleanObject(evaluated, val)
+
+		case string:
+			e.testErrorObject(evaluated, val)
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestFilesModule() {
+	dir := e.T().TempDir()
+	tests := []tuple[interface{}]{
+		{
+			source: `
+			importar "archivos";
+			archivos.escribir("{dir}/a.txt", "hola");
+			archivos.agregar("{dir}/a.txt", " mundo");
+			archivos.leer("{dir}/a.txt");
+			`,
+			expected: "hola mundo",
+		},
+		{source: `importar "archivos"; archivos.existe("{dir}/a.txt");`, expected: true},
+		{source: `importar "archivos"; archivos.existe("{dir}/b.txt");`, expected: false},
+		{source: `importar "archivos"; archivos.leer("{dir}/b.txt");`, expected: "la ruta {dir}/b.txt no existe"},
+		{
+			source: `
+			importar "archivos";
+			archivos.crear_directorio("{dir}/datos");
+			archivos.listar_directorio("{dir}");
+			`,
+			expected: []string{"a.txt", "datos"},
+		},
+		{
+			source: `
+			importar "archivos";
+			archivos.eliminar("{dir}/datos");
+			archivos.listar_directorio("{dir}");
+			`,
+			expected: []string{"a.txt"},
+		},
+		{source: `importar "archivos"; archivos.escribir("{dir}/a.txt", 5);`, expected: "argumento para escribir no valido, se recibio entero"},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(strings.ReplaceAll(test.source, "{dir}", dir))
+		switch val := test.expected.(type) {
+		case bool:
+			e.testBooleanObject(evaluated, val)
+
+		case []string:
+			e.testStringArrayObject(evaluated, val)
+
+		case string:
+			expected := strings.ReplaceAll(val, "{dir}", dir)
+			if str, isStr := evaluated.(*obj.String); isStr {
+				e.testStringObject(str, expected)
+			} else {
+				e.testErrorObject(evaluated, expected)
+			}
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestFileLines() {
+	dir := e.T().TempDir()
+	source := fmt.Sprintf(`
+		importar "archivos";
+		archivos.escribir("%s/a.txt", "uno
+dos");
+		resultado := "";
+		por(linea en archivos.lineas("%s/a.txt")) {
+			resultado += linea;
+			resultado += ",";
+		}
+		resultado;
+	`, dir, dir)
+
+	e.testStringObject(e.evaluateTests(source), "uno,dos,")
+}
+
+func (e *EvaluatorTests) TestFileLinesReadError() {
+	dir := e.T().TempDir()
+	path := filepath.Join(dir, "a.txt")
+	// the scanner fails on a line longer than its buffer
+	content := "uno\ndos\n" + strings.Repeat("x", 100000)
+	e.Require().NoError(os.WriteFile(path, []byte(content), 0644))
+
+	sources := []string{
+		`importar "archivos"; n := 0; por(linea en archivos.lineas("%s")) { n += 1; } n;`,
+		`importar "archivos"; lista[linea por linea en archivos.lineas("%s")];`,
+	}
+
+	for _, source := range sources {
+		evaluated := e.evaluateTests(fmt.Sprintf(source, path))
+		e.testErrorObject(evaluated, fmt.Sprintf("no se pudo leer el archivo %s", path))
+	}
+}
+
+func (e *EvaluatorTests) TestFileLinesClosedOnBreak() {
+	if runtime.GOOS != "linux" {
+		e.T().Skip("the open files are counted in /proc")
+	}
+
+	dir := e.T().TempDir()
+	e.Require().NoError(os.WriteFile(filepath.Join(dir, "a.txt"), []byte("uno\ndos\ntres"), 0644))
+	source := fmt.Sprintf(`
+		importar "archivos";
+		por(i en rango(50)) {
+			por(linea en archivos.lineas("%s/a.txt")) {
+				romper;
+			}
+		}
+	`, dir)
+
+	openFiles := func() int {
+		entries, err := os.ReadDir("/proc/self/fd")
+		e.Require().NoError(err)
+		return len(entries)
+	}
+
+	before := openFiles()
+	e.evaluateTests(source)
+	e.Less(openFiles()-before, 5)
+}
+
+func (e *EvaluatorTests) TestFilesSandbox() {
+	dir := e.T().TempDir()
+	outside := e.T().TempDir()
+	e.Require().NoError(os.WriteFile(filepath.Join(outside, "secreto.txt"), []byte("secreto"), 0644))
+	e.Require().NoError(os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	// links inside the root that point outside of it and inside of it
+	e.Require().NoError(os.Symlink(outside, filepath.Join(dir, "fuera")))
+	e.Require().NoError(os.Symlink(filepath.Join(outside, "nuevo.txt"), filepath.Join(dir, "enlace.txt")))
+	e.Require().NoError(os.Symlink("sub", filepath.Join(dir, "dentro")))
+
+	e.Require().NoError(b.SetSandboxRoot(dir))
+	defer b.SetSandboxRoot("")
+
+	tests := []tuple[string]{
+		{`importar "archivos"; archivos.escribir("a.txt", "hola"); archivos.leer("a.txt");`, "hola"},
+		{`importar "archivos"; archivos.leer("../a.txt");`, "la ruta ../a.txt esta fuera del directorio permitido"},
+		{`importar "archivos"; archivos.leer("/etc/hosts");`, "la ruta /etc/hosts esta fuera del directorio permitido"},
+		{`importar "archivos"; archivos.leer("fuera/secreto.txt");`, "la ruta fuera/secreto.txt esta fuera del directorio permitido"},
+		{`importar "archivos"; archivos.escribir("enlace.txt", "x");`, "la ruta enlace.txt esta fuera del directorio permitido"},
+		{`importar "archivos"; archivos.escribir("dentro/b.txt", "adentro"); archivos.leer("sub/b.txt");`, "adentro"},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(test.source)
+		if str, isStr := evaluated.(*obj.String); isStr {
+			e.testStringObject(str, test.expected)
+		} else {
+			e.testErrorObject(evaluated, test.expected)
+		}
+	}
+
+	_, err := os.Stat(filepath.Join(outside, "nuevo.txt"))
+	e.True(os.IsNotExist(err))
+}
+
+func (e *EvaluatorTests) TestJSONModule() {
+	dir := e.T().TempDir()
+	files := map[string]string{
+		"datos.json":    `{"nombre": "aura", "version": 1, "tags": ["a", 2.5, true, null]}`,
+		"invalido.json": `{"nombre": `,
+	}
+	for name, content := range files {
+		e.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	tests := []tuple[interface{}]{
+		{source: `d := json.decodificar(archivos.leer("{dir}/datos.json")); d["nombre"];`, expected: "aura"},
+		{source: `d := json.decodificar(archivos.leer("{dir}/datos.json")); d["version"];`, expected: 1},
+		{source: `d := json.decodificar(archivos.leer("{dir}/datos.json")); d["tags"][1];`, expected: 2.5},
+		{source: `d := json.decodificar(archivos.leer("{dir}/datos.json")); d["tags"][3] == nulo;`, expected: true},
+		{
+			source:   `json.decodificar(archivos.leer("{dir}/invalido.json"));`,
+			expected: expectedError("el texto no es un json valido, error cerca de la posicion 10"),
+		},
+		{
+			source:   `d := json.decodificar(archivos.leer("{dir}/datos.json")); json.codificar(d);`,
+			expected: `{"nombre":"aura","version":1,"tags":["a",2.5,true,null]}`,
+		},
+		{source: `json.codificar(lista[1, 2.0, "b"]);`, expected: `[1,2.0,"b"]`},
+		{source: `json.codificar(mapa{"a" => tupla(1, tupla("b"))});`, expected: `{"a":[1,["b"]]}`},
+		{source: `json.codificar(mapa{1 => "a", 2 => "b"});`, expected: `{"1":"a","2":"b"}`},
+		{
+			source:   `json.codificar(1, 2, 3);`,
+			expected: expectedError("numero incorrecto de argumentos para codificar, se recibieron 3, se requieren entre 1 y 2"),
+		},
+		{
+			source:   `json.codificar(mapa{1 => "a", "1" => "b"});`,
+			expected: expectedError("no se puede convertir a json el mapa, la llave 1 se repite como texto"),
+		},
+		{
+			source:   `l := lista[1]; t := tupla(l); l:agregar(t); json.codificar(l);`,
+			expected: expectedError("no se puede convertir a json un objeto que se contiene a si mismo"),
+		},
+		{source: `json.codificar(mapa{"a" => lista[1]}, verdadero);`, expected: "{\n  \"a\": [\n    1\n  ]\n}"},
+		{
+			source:   `clase Punto(x, y) { a_json() => lista[x, y] } json.codificar(nuevo Punto(1, 2));`,
+			expected: "[1,2]",
+		},
+		{
+			source:   `clase Punto(x, y) {} json.codificar(nuevo Punto(1, 2));`,
+			expected: expectedError("no se puede convertir a json la clase Punto, debe definir el metodo a_json"),
+		},
+		{
+			source:   `json.codificar(|x| => x);`,
+			expected: expectedError("no se puede convertir a json un objeto de tipo funcion"),
+		},
+		{
+			source:   `m := mapa{"a" => 1}; m["b"] = m; json.codificar(m);`,
+			expected: expectedError("no se puede convertir a json un objeto que se contiene a si mismo"),
+		},
+		{source: `json.decodificar(5);`, expected: expectedError("argumento para decodificar no valido, se recibio entero")},
+		{source: `json.decodificar("123456789012345678901234567890") == 123456789012345678901234567890;`, expected: true},
+		{source: `json.codificar(json.decodificar("[-123456789012345678901234567890,1e3]"));`, expected: "[-123456789012345678901234567890,1000.0]"},
+	}
+
+	for _, test := range tests {
+		source := `importar "json"; importar "archivos"; ` + strings.ReplaceAll(test.source, "{dir}", dir)
+		evaluated := e.evaluateTests(source)
+		switch val := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, val)
+
+		case float64:
+			e.testFloatObject(evaluated, val)
+
+		case bool:
+			e.testBooleanObject(evaluated, val)
+
+		case string:
+			e.testStringObject(evaluated, val)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(val))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestCSVModule() {
+	dir := e.T().TempDir()
+	files := map[string]string{
+		"datos.csv":    "nombre,edad\nana,30\n\"perez, juan\",25\n",
+		"puntos.csv":   "a;b\n1;2\n",
+		"invalido.csv": "a,\"b\nc\n",
+		"faltante.csv": "a,b\n1\n",
+	}
+	for name, content := range files {
+		e.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	tests := []tuple[interface{}]{
+		{source: `filas := csv.leer("{dir}/datos.csv"); largo(filas);`, expected: 3},
+		{source: `filas := csv.leer("{dir}/datos.csv"); filas[2][0];`, expected: "perez, juan"},
+		{source: `filas := csv.leer("{dir}/datos.csv", mapa{"encabezados" => verdadero}); filas[0]["edad"];`, expected: "30"},
+		{source: `filas := csv.leer("{dir}/puntos.csv", mapa{"delimitador" => ";"}); filas[1][1];`, expected: "2"},
+		{source: `filas := csv.decodificar("x,y"); filas[0][1];`, expected: "y"},
+		{
+			source: `
+				nombres := "";
+				por(fila en csv.filas("{dir}/datos.csv", mapa{"encabezados" => verdadero})) {
+					nombres += fila["nombre"];
+					nombres += "|";
+				}
+				nombres;
+			`,
+			expected: "ana|perez, juan|",
+		},
+		{
+			source:   `csv.codificar(lista[mapa{"a" => 1, "b" => "x,y"}, mapa{"a" => 2, "c" => nulo}]);`,
+			expected: "a,b,c\n1,\"x,y\",\n2,,\n",
+		},
+		{source: `csv.codificar(lista[lista[1, 2], lista[3, 4]], mapa{"delimitador" => ";"});`, expected: "1;2\n3;4\n"},
+		{
+			source: `
+				filas := csv.leer("{dir}/datos.csv", mapa{"encabezados" => verdadero});
+				csv.escribir("{dir}/copia.csv", filas);
+				archivos.leer("{dir}/copia.csv");
+			`,
+			expected: "nombre,edad\nana,30\n\"perez, juan\",25\n",
+		},
+		{
+			source:   `csv.leer("{dir}/invalido.csv");`,
+			expected: expectedError("el csv no es valido, error en la linea 2 columna 3"),
+		},
+		{
+			source:   `csv.leer("{dir}/faltante.csv", mapa{"encabezados" => verdadero});`,
+			expected: expectedError("la fila tiene 1 campos pero el encabezado tiene 2"),
+		},
+		{
+			source:   `n := 0; por(fila en csv.filas("{dir}/invalido.csv")) { n += 1; } n;`,
+			expected: expectedError("el csv no es valido, error en la linea 2 columna 3"),
+		},
+		{
+			source:   `lista[fila por fila en csv.filas("{dir}/invalido.csv")];`,
+			expected: expectedError("el csv no es valido, error en la linea 2 columna 3"),
+		},
+		{
+			source:   `n := 0; por(fila en csv.filas("{dir}/faltante.csv", mapa{"encabezados" => verdadero})) { n += 1; } n;`,
+			expected: expectedError("la fila tiene 1 campos pero el encabezado tiene 2"),
+		},
+		{
+			source:   `csv.decodificar("a", mapa{"delimitador" => "ab"});`,
+			expected: expectedError("el delimitador debe ser un solo caracter"),
+		},
+		{
+			source:   `csv.codificar(lista[1]);`,
+			expected: expectedError("las filas del csv deben ser listas o mapas, se recibio entero"),
+		},
+		{
+			source:   `csv.escribir("{dir}/puntos.csv", lista[lista["x"], 1]);`,
+			expected: expectedError("las filas del csv deben ser listas o mapas, se recibio entero"),
+		},
+		// the invalid row did not touch the existing file
+		{source: `archivos.leer("{dir}/puntos.csv");`, expected: "a;b\n1;2\n"},
+		{
+			source:   `csv.escribir("{dir}/puntos.csv");`,
+			expected: expectedError("numero incorrecto de argumentos para escribir, se recibieron 1, se requieren entre 2 y 3"),
+		},
+		{
+			source:   `csv.leer();`,
+			expected: expectedError("numero incorrecto de argumentos para leer, se recibieron 0, se requieren entre 1 y 2"),
+		},
+	}
+
+	for _, test := range tests {
+		source := `importar "csv"; importar "archivos"; ` + strings.ReplaceAll(test.source, "{dir}", dir)
+		evaluated := e.evaluateTests(source)
+		switch val := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, val)
+
+		case string:
+			e.testStringObject(evaluated, val)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(val))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestRegexModule() {
+	tests := []tuple[interface{}]{
+		{source: `r := regex.compilar("\d+"); r.coincide("abc123");`, expected: true},
+		{source: `r := regex.compilar("^\d+$"); r.coincide("abc123");`, expected: false},
+		{source: `r := regex.compilar("\d+"); r.buscar("abc") == nulo;`, expected: true},
+		{source: `r := regex.compilar("\d+"); m := r.buscar("el 2024"); m.texto;`, expected: "2024"},
+		{source: `r := regex.compilar("\d+"); m := r.buscar("el 2024"); m.inicio;`, expected: 3},
+		{source: `r := regex.compilar("\d+"); m := r.buscar("el 2024"); m.fin;`, expected: 7},
+		{source: `r := regex.compilar("(\w+)@(\w+)"); m := r.buscar("hola ana@aura"); m.grupos[1];`, expected: "aura"},
+		{source: `r := regex.compilar("(\w+)@(\w+)"); m := r.buscar("hola ana@aura"); m.grupo(0);`, expected: "ana@aura"},
+		{source: `r := regex.compilar("(?P<dia>\d+)/(?P<mes>\d+)"); m := r.buscar("el 12/05"); m.nombrados["mes"];`, expected: "05"},
+		{source: `r := regex.compilar("(?P<dia>\d+)/(?P<mes>\d+)"); m := r.buscar("el 12/05"); m.grupo("dia");`, expected: "12"},
+		{source: `r := regex.compilar("a(b)?"); m := r.buscar("a"); m.grupo(1) == nulo;`, expected: true},
+		{source: `r := regex.compilar("\d"); largo(r.buscar_todos("a1b2c3"));`, expected: 3},
+		{source: `r := regex.compilar("\d"); r.buscar_todos("a1b2c3")[2].texto;`, expected: "3"},
+		{source: `r := regex.compilar("(\w+)@(\w+)"); r.reemplazar("ana@aura", "$2 de $1");`, expected: "aura de ana"},
+		{source: `r := regex.compilar("(?P<n>\d+)"); r.reemplazar("a1b22", "<${n}>");`, expected: "a<1>b<22>"},
+		{
+			source:   `r := regex.compilar("\d+"); r.reemplazar("a1b22", funcion(m) { regresa texto(entero(m.texto) * 2) });`,
+			expected: "a2b44",
+		},
+		{source: `r := regex.compilar(",\s*"); r.dividir("a, b,c")[2];`, expected: "c"},
+		{source: `r := regex.compilar(","); largo(r.dividir("a,b,c", 2));`, expected: 2},
+		{source: `r := regex.compilar("\d"); r.patron;`, expected: "\\d"},
+		{source: `r := regex.compilar(r"\d\.\d"); r.coincide("1.5");`, expected: true},
+		{source: `regex.compilar("(");`, expected: expectedError("el patron ( no es valido")},
+		{source: `r := regex.compilar("a"); r.buscar("a").grupo(3);`, expected: expectedError("el grupo 3 no existe")},
+		{
+			source:   `r := regex.compilar("a"); r.reemplazar("a", funcion(m) { regresa 1 });`,
+			expected: expectedError("la funcion de reemplazo debe regresar un texto, se recibio entero"),
+		},
+		{source: `r := regex.compilar("a"); r.separar("a");`, expected: expectedError("regex a no tiene el miembro separar")},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(`importar "regex"; ` + test.source)
+		switch val := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, val)
+
+		case bool:
+			e.testBooleanObject(evaluated, val)
+
+		case string:
+			e.testStringObject(evaluated, val)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(val))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestTimeModule() {
+	tests := []tuple[interface{}]{
+		{source: `f := tiempo.fecha(2024, 5, 12, "UTC"); f.anio;`, expected: 2024},
+		{source: `f := tiempo.fecha(2024, 5, 12, 10, 30, 0, "UTC"); f.minuto;`, expected: 30},
+		{source: `f := tiempo.fecha(2024, 5, 12, "UTC"); f.dia_semana;`, expected: "domingo"},
+		{source: `f := tiempo.fecha(2024, 5, 12, "UTC"); f.nombre_mes;`, expected: "mayo"},
+		{
+			source:   `f := tiempo.fecha(2024, 5, 12, 9, 5, 0, "UTC"); f.formatear("%A %d de %B de %Y, %H:%M");`,
+			expected: "domingo 12 de mayo de 2024, 09:05",
+		},
+		{source: `f := tiempo.fecha(2024, 5, 12, "UTC"); f.formatear("%a %b %y %%");`, expected: "dom may 24 %"},
+		{source: `f := tiempo.fecha(2024, 12, 31, "UTC") + tiempo.dias(1); f.anio;`, expected: 2025},
+		{source: `f := tiempo.fecha(2024, 3, 1, "UTC") - tiempo.horas(1); f.dia;`, expected: 29},
+		{source: `d := tiempo.fecha(2024, 5, 12, "UTC") - tiempo.fecha(2024, 5, 10, "UTC"); d.horas;`, expected: 48.0},
+		{source: `tiempo.fecha(2024, 5, 10, "UTC") < tiempo.fecha(2024, 5, 12, "UTC");`, expected: true},
+		{source: `tiempo.fecha(2024, 5, 10, "UTC") >= tiempo.fecha(2024, 5, 12, "UTC");`, expected: false},
+		{source: `tiempo.fecha(2024, 5, 10, "UTC") == tiempo.fecha(2024, 5, 10, "UTC");`, expected: true},
+		{source: `tiempo.fecha(2024, 5, 10, "UTC") == nulo;`, expected: false},
+		{source: `tiempo.minutos(90) == tiempo.horas(1.5);`, expected: true},
+		{source: `tiempo.segundos(1) < tiempo.milisegundos(999);`, expected: false},
+		{source: `d := tiempo.minutos(2) * 3; d.minutos;`, expected: 6.0},
+		{source: `d := tiempo.horas(1) + tiempo.minutos(30); texto(d);`, expected: "1h30m0s"},
+		{source: `f := tiempo.fecha(2024, 5, 12, 12, 0, 0, "UTC"); f.en_zona("America/Mexico_City").hora;`, expected: 6},
+		{source: `f := tiempo.analizar("12/05/2024 08:15", "%d/%m/%Y %H:%M", "UTC"); f.hora;`, expected: 8},
+		{source: `f := tiempo.analizar("3 de marzo de 2023", "%d de %B de %Y", "UTC"); f.mes;`, expected: 3},
+		{source: `f := tiempo.analizar("2024-05-12 10:00 -0600", "%Y-%m-%d %H:%M %z"); f.en_zona("UTC").hora;`, expected: 16},
+		{
+			source:   `tiempo.analizar("2024/05/12", "%Y-%m-%d");`,
+			expected: expectedError("el texto 2024/05/12 no coincide con el formato %Y-%m-%d"),
+		},
+		{source: `tiempo.fecha(2024, 2, 30);`, expected: expectedError("la fecha 2024-02-30 00:00:00 no es valida")},
+		{source: `tiempo.fecha(2024, 2, 3, "Marte/Base");`, expected: expectedError("la zona horaria Marte/Base no existe")},
+		{source: `f := tiempo.ahora(); f.formatear("%Q");`, expected: expectedError("la directiva de formato %Q no es valida")},
+		{source: `tiempo.ahora() < tiempo.dias(1);`, expected: expectedError("Discrepancia de tipos: fecha < duracion")},
+		{source: `tipo(tiempo.ahora());`, expected: "fecha"},
+		{source: `c := tiempo.cronometro(); d := c.transcurrido(); d >= tiempo.segundos(0);`, expected: true},
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(`importar "tiempo"; ` + test.source)
+		switch val := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, val)
+
+		case float64:
+			e.testFloatObject(evaluated, val)
+
+		case bool:
+			e.testBooleanObject(evaluated, val)
+
+		case string:
+			e.testStringObject(evaluated, val)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(val))
+		}
+	}
+}
+
+func (e *EvaluatorTests) TestSystemModule() {
+	b.SetScriptArgs([]string{"uno", "--dos"})
+	defer b.SetScriptArgs(nil)
+	e.T().Setenv("AURA_PRUEBA", "valor")
+
+	tests := []tuple[interface{}]{
+		{source: `largo(sistema.argumentos());`, expected: 2},
+		{source: `sistema.argumentos()[1];`, expected: "--dos"},
+		{source: `sistema.entorno("AURA_PRUEBA");`, expected: "valor"},
+		{source: `sistema.entorno("AURA_NO_EXISTE") == nulo;`, expected: true},
+		{source: `sistema.entorno("AURA_NO_EXISTE", "defecto");`, expected: "defecto"},
+		{
+			source:   `sistema.entorno();`,
+			expected: expectedError("numero incorrecto de argumentos para entorno, se recibieron 0, se requieren entre 1 y 2"),
+		},
+		{
+			source:   `sistema.entorno("A", "b", "c");`,
+			expected: expectedError("numero incorrecto de argumentos para entorno, se recibieron 3, se requieren entre 1 y 2"),
+		},
+		{source: `sistema.plataforma();`, expected: runtime.GOOS},
+		{source: `sistema.salir("uno");`, expected: expectedError("argumento para salir no valido, se recibio texto")},
+		{
+			source:   `sistema.ejecutar("comando-que-no-existe");`,
+			expected: expectedError("no se pudo ejecutar el comando comando-que-no-existe"),
+		},
+	}
+
+	if runtime.GOOS != "windows" {
+		tests = append(tests, []tuple[interface{}]{
+			{source: `r := sistema.ejecutar("sh", lista["-c", "echo hola; echo mal >&2; exit 3"]); r["salida"];`, expected: "hola\n"},
+			{source: `r := sistema.ejecutar("sh", lista["-c", "echo hola; echo mal >&2; exit 3"]); r["errores"];`, expected: "mal\n"},
+			{source: `r := sistema.ejecutar("sh", lista["-c", "echo hola; echo mal >&2; exit 3"]); r["codigo"];`, expected: 3},
+			{source: `r := sistema.ejecutar("echo", lista["a", 1]); r["salida"];`, expected: "a 1\n"},
+		}...)
+	}
+
+	for _, test := range tests {
+		evaluated := e.evaluateTests(`importar "sistema"; ` + test.source)
+		switch val := test.expected.(type) {
+		case int:
+			e.testIntegerObject(evaluated, val)
+
+		case bool:
+			e.testBooleanObject(evaluated, val)
+
+		case string:
+			e.testStringObject(evaluated, val)
+
+		case expectedError:
+			e.testErrorObject(evaluated, string(val))
+		}
+	}
+}
+
 func (e *EvaluatorTests) testErrorObject(evlauated obj.Object, expected string) {
 	if !e.IsType(&obj.Error{}, evlauated) {
 		e.T().FailNow()
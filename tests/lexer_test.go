@@ -2,7 +2,12 @@ package test
 
 import (
 	"aura/src/lexer"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"unicode/utf8"
 
 	"github.com/stretchr/testify/suite"
@@ -12,34 +17,39 @@ type LexerTests struct {
 	suite.Suite
 }
 
+// read the tokens without their positions, the positions are checked in
+// TestTokenPositions
 func (l *LexerTests) loadTokens(length int, source string) []*lexer.Token {
 	lex := lexer.NewLexer(source)
 	var tokens []*lexer.Token
 
 	for i := 0; i < length; i++ {
-		tokens = append(tokens, lex.NextToken())
+		token := lex.NextToken()
+		token.Line, token.Column = 0, 0
+		tokens = append(tokens, token)
 	}
 
 	return tokens
 }
 
 func (l *LexerTests) TestIllegalToken() {
-	source := "¡¿@&"
+	source := "¡¿@$"
 	tokens := l.loadTokens(utf8.RuneCountInString(source), source)
 
 	expectedTokens := []*lexer.Token{
 		{Token_type: lexer.ILLEGAL, Literal: "¡"},
 		{Token_type: lexer.ILLEGAL, Literal: "¿"},
 		{Token_type: lexer.ILLEGAL, Literal: "@"},
-		{Token_type: lexer.ILLEGAL, Literal: "&"},
+		{Token_type: lexer.ILLEGAL, Literal: "$"},
 	}
 
 	l.Assert().Equal(expectedTokens, tokens)
 }
 
 func (l *LexerTests) TestOneCharacterOperator() {
-	source := "+-/*<>!%="
-	tokens := l.loadTokens(utf8.RuneCountInString(source), source)
+	// the space keeps / * from opening a block comment
+	source := "+-/ *<>!%=&"
+	tokens := l.loadTokens(utf8.RuneCountInString(source)-1, source)
 
 	expectedTokens := []*lexer.Token{
 		{Token_type: lexer.PLUS, Literal: "+"},
@@ -51,6 +61,7 @@ func (l *LexerTests) TestOneCharacterOperator() {
 		{Token_type: lexer.NOT, Literal: "!"},
 		{Token_type: lexer.MOD, Literal: "%"},
 		{Token_type: lexer.ASSING, Literal: "="},
+		{Token_type: lexer.AMPERSAND, Literal: "&"},
 	}
 
 	l.Assert().Equal(expectedTokens, tokens)
@@ -68,6 +79,42 @@ func (l *LexerTests) TestFloat() {
 	l.Assert().Equal(expectedTokens, tokens)
 }
 
+func (l *LexerTests) TestTokenPositions() {
+	source := "x := 1;\n  // comentario\n  y := \"año\" + 2.5;"
+	lex := lexer.NewLexer(source)
+
+	expected := [][2]int{{1, 1}, {1, 3}, {1, 6}, {1, 7}, {3, 3}, {3, 5}, {3, 8}, {3, 14}, {3, 16}, {3, 19}}
+	for _, position := range expected {
+		token := lex.NextToken()
+		l.Assert().Equal(position, [2]int{token.Line, token.Column}, token.Literal)
+	}
+}
+
+func (l *LexerTests) TestNumberLiterals() {
+	source := "0xFF 0b1010 0o17 1_000_000 1.5e-3 2E10 .5 1_000.25 007 0b102 5.metodo 2e"
+
+	tokens := l.loadTokens(15, source)
+	expectedTokens := []*lexer.Token{
+		{Token_type: lexer.INT, Literal: "0xFF"},
+		{Token_type: lexer.INT, Literal: "0b1010"},
+		{Token_type: lexer.INT, Literal: "0o17"},
+		{Token_type: lexer.INT, Literal: "1_000_000"},
+		{Token_type: lexer.FLOAT, Literal: "1.5e-3"},
+		{Token_type: lexer.FLOAT, Literal: "2E10"},
+		{Token_type: lexer.FLOAT, Literal: ".5"},
+		{Token_type: lexer.FLOAT, Literal: "1_000.25"},
+		{Token_type: lexer.INT, Literal: "007"},
+		{Token_type: lexer.INT, Literal: "0b102"},
+		{Token_type: lexer.INT, Literal: "5"},
+		{Token_type: lexer.DOT, Literal: "."},
+		{Token_type: lexer.IDENT, Literal: "metodo"},
+		{Token_type: lexer.INT, Literal: "2"},
+		{Token_type: lexer.IDENT, Literal: "e"},
+	}
+
+	l.Assert().Equal(expectedTokens, tokens)
+}
+
 func (l *LexerTests) TestClassDeclaration() {
 	source := `
 		clase Persona(nombre, edad) {}
@@ -340,6 +387,293 @@ func (l *LexerTests) TestString() {
 	l.Assert().Equal(expectedTokens, tokens)
 }
 
+func (l *LexerTests) TestStringEscapes() {
+	source := `"a\tb\n" 'dice "hola" y \'adios\'' "\u00f1\u{1F600}" "\d\\" r"\d+\n\"" r'C:\ruta'`
+
+	tokens := l.loadTokens(6, source)
+	expectedTokens := []*lexer.Token{
+		{Token_type: lexer.STRING, Literal: "a\tb\n"},
+		{Token_type: lexer.STRING, Literal: `dice "hola" y 'adios'`},
+		{Token_type: lexer.STRING, Literal: "ñ😀"},
+		{Token_type: lexer.STRING, Literal: `\d\`},
+		{Token_type: lexer.STRING, Literal: `\d+\n\"`},
+		{Token_type: lexer.STRING, Literal: `C:\ruta`},
+	}
+
+	l.Assert().Equal(expectedTokens, tokens)
+}
+
+func (l *LexerTests) TestMultilineString() {
+	source := "\"\"\"linea 1\nlinea \"2\" con ''\"\"\"; r'''sin \\n'''; \"a\\\nb\";"
+
+	tokens := l.loadTokens(6, source)
+	expectedTokens := []*lexer.Token{
+		{Token_type: lexer.STRING, Literal: "linea 1\nlinea \"2\" con ''"},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+		{Token_type: lexer.STRING, Literal: `sin \n`},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+		{Token_type: lexer.STRING, Literal: "ab"},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+	}
+
+	l.Assert().Equal(expectedTokens, tokens)
+}
+
+func (l *LexerTests) TestStringErrors() {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"x := 1;\ny := \"abc;", "texto sin terminar en la linea 2, columna 6"},
+		{`"""abc""`, "texto sin terminar en la linea 1, columna 1"},
+		{`f"{x}`, "texto sin terminar en la linea 1, columna 2"},
+		{`"\u{zz}"`, `secuencia de escape unicode no valida \uzz en la linea 1, columna 2`},
+	}
+
+	for _, test := range tests {
+		lex := lexer.NewLexer(test.source)
+		for token := lex.NextToken(); token.Token_type != lexer.EOF; token = lex.NextToken() {
+		}
+
+		l.Assert().Equal([]string{test.expected}, lex.Errors())
+	}
+}
+
+func (l *LexerTests) TestInterpolatedString() {
+	source := `f"hola {nombre}, {{ok}} {m["a"] + 1}!"; f'';`
+
+	tokens := l.loadTokens(4, source)
+	expectedTokens := []*lexer.Token{
+		{
+			Token_type: lexer.FSTRING,
+			Literal:    `hola {nombre}, {{ok}} {m["a"] + 1}!`,
+			Parts: []lexer.StringPart{
+				{Value: "hola "},
+				{Value: "nombre", IsExpression: true},
+				{Value: ", {ok} "},
+				{Value: `m["a"] + 1`, IsExpression: true},
+				{Value: "!"},
+			},
+		},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+		{Token_type: lexer.FSTRING, Literal: ""},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+	}
+
+	l.Assert().Equal(expectedTokens, tokens)
+}
+
+func (l *LexerTests) TestBlockComments() {
+	source := "x /* comentario\n /* anidado */ */ := 5; // final"
+
+	tokens := l.loadTokens(4, source)
+	expectedTokens := []*lexer.Token{
+		{Token_type: lexer.IDENT, Literal: "x"},
+		{Token_type: lexer.COLONASSING, Literal: ":="},
+		{Token_type: lexer.INT, Literal: "5"},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+	}
+
+	l.Assert().Equal(expectedTokens, tokens)
+
+	lex := lexer.NewLexer("x := 1;\n /* abierto /* */")
+	for token := lex.NextToken(); token.Token_type != lexer.EOF; token = lex.NextToken() {
+	}
+
+	l.Assert().Equal([]string{"comentario sin terminar en la linea 2, columna 2"}, lex.Errors())
+}
+
+func (l *LexerTests) TestDocComments() {
+	source := "/// Suma dos numeros.\n///Regresa el total.\nfuncion suma(a, b) {}\n//// normal\nx"
+
+	lex := lexer.NewLexer(source)
+	function := lex.NextToken()
+	l.Assert().Equal(lexer.FUNCTION, function.Token_type)
+	l.Assert().Equal("Suma dos numeros.\nRegresa el total.", function.Doc)
+
+	for token := lex.NextToken(); token.Token_type != lexer.EOF; token = lex.NextToken() {
+		l.Assert().Equal("", token.Doc)
+	}
+}
+
+func (l *LexerTests) TestMultibyteCharacters() {
+	source := "señal := \"ñandú 😀\" + acción; días.año_nuevo; 5;"
+
+	tokens := l.loadTokens(12, source)
+	expectedTokens := []*lexer.Token{
+		{Token_type: lexer.IDENT, Literal: "señal"},
+		{Token_type: lexer.COLONASSING, Literal: ":="},
+		{Token_type: lexer.STRING, Literal: "ñandú 😀"},
+		{Token_type: lexer.PLUS, Literal: "+"},
+		{Token_type: lexer.IDENT, Literal: "acción"},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+		{Token_type: lexer.IDENT, Literal: "días"},
+		{Token_type: lexer.DOT, Literal: "."},
+		{Token_type: lexer.IDENT, Literal: "año_nuevo"},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+		{Token_type: lexer.INT, Literal: "5"},
+		{Token_type: lexer.SEMICOLON, Literal: ";"},
+	}
+
+	l.Assert().Equal(expectedTokens, tokens)
+
+	// the columns are counted in characters not in bytes
+	lex := lexer.NewLexer(source)
+	expected := []int{1, 7, 10, 20, 22, 28, 30, 34, 35, 44, 46, 47}
+	for _, column := range expected {
+		token := lex.NextToken()
+		l.Assert().Equal(column, token.Column, token.Literal)
+	}
+}
+
+func (l *LexerTests) TestReaderLexer() {
+	sources := []string{
+		"señal := \"ñandú 😀\" + acción; // fin",
+		"/// doc\nfuncion f(x) { regresa x ** 2 /* ok */; }\n",
+		`f"{a} {{b}}" + r'\d' + """varias
+lineas""" + 1_000.5e-3`,
+		"x /* sin terminar",
+		"",
+	}
+
+	for _, source := range sources {
+		expected := lexer.NewLexer(source)
+		// one byte at a time splits the runes and the tokens between reads
+		streamed := lexer.NewReaderLexer(iotest.OneByteReader(strings.NewReader(source)))
+
+		for {
+			token := streamed.NextToken()
+			l.Assert().Equal(expected.NextToken(), token, source)
+			if token.Token_type == lexer.EOF {
+				break
+			}
+		}
+
+		l.Assert().Equal(expected.Errors(), streamed.Errors())
+	}
+}
+
+func (l *LexerTests) TestReaderLexerError() {
+	reader := io.MultiReader(strings.NewReader("x := 1"), iotest.ErrReader(errors.New("disco")))
+	lex := lexer.NewReaderLexer(reader)
+
+	var tokens []string
+	for token := lex.NextToken(); token.Token_type != lexer.EOF; token = lex.NextToken() {
+		tokens = append(tokens, token.Literal)
+	}
+
+	l.Assert().Equal([]string{"x", ":=", "1"}, tokens)
+	l.Assert().Equal([]string{"no se pudo leer el codigo: disco en la linea 1, columna 3"}, lex.Errors())
+}
+
+func (l *LexerTests) TestUnicodeIdentifiers() {
+	// the last identifier has an e followed by a combining accent
+	source := "pingüino façade 日本語 δx1 _x x٣ é ¿ 😀"
+
+	tokens := l.loadTokens(11, source)
+	expectedTokens := []*lexer.Token{
+		{Token_type: lexer.IDENT, Literal: "pingüino"},
+		{Token_type: lexer.IDENT, Literal: "façade"},
+		{Token_type: lexer.IDENT, Literal: "日本語"},
+		{Token_type: lexer.IDENT, Literal: "δx1"},
+		{Token_type: lexer.IDENT, Literal: "_x"},
+		{Token_type: lexer.IDENT, Literal: "x٣"},
+		{Token_type: lexer.IDENT, Literal: "é"},
+		{Token_type: lexer.ILLEGAL, Literal: "¿"},
+		{Token_type: lexer.ILLEGAL, Literal: "😀"},
+		{Token_type: lexer.EOF, Literal: ""},
+		{Token_type: lexer.EOF, Literal: ""},
+	}
+
+	l.Assert().Equal(expectedTokens, tokens)
+}
+
+func (l *LexerTests) TestKeywordLanguages() {
+	tests := []struct {
+		source   string
+		expected []lexer.TokenType
+	}{
+		{"si verdadero intentar continuar if", []lexer.TokenType{lexer.IF, lexer.TRUE, lexer.TRY, lexer.CONTINUE, lexer.IDENT}},
+		{"// idioma: en\nif true try continue si", []lexer.TokenType{lexer.IF, lexer.TRUE, lexer.TRY, lexer.CONTINUE, lexer.IDENT}},
+		{"/* licencia */\n//idioma:pt\nse verdadeiro tentar continuar if", []lexer.TokenType{lexer.IF, lexer.TRUE, lexer.TRY, lexer.CONTINUE, lexer.IDENT}},
+		// the language can only be chosen before the first token
+		{"si\n// idioma: en\nif", []lexer.TokenType{lexer.IF, lexer.IDENT}},
+	}
+
+	for _, test := range tests {
+		lex := lexer.NewLexer(test.source)
+		var types []lexer.TokenType
+		for token := lex.NextToken(); token.Token_type != lexer.EOF; token = lex.NextToken() {
+			types = append(types, token.Token_type)
+		}
+
+		l.Assert().Equal(test.expected, types, test.source)
+		l.Assert().Empty(lex.Errors())
+	}
+
+	lex := lexer.NewLexer("\n  // idioma: fr\nsi")
+	l.Assert().Equal(lexer.IF, lex.NextToken().Token_type)
+	l.Assert().Equal([]string{"idioma desconocido fr, los idiomas son en, es, pt en la linea 2, columna 3"}, lex.Errors())
+}
+
+func (l *LexerTests) TestDefaultLanguage() {
+	defer lexer.SetDefaultLanguage("es")
+
+	l.Assert().NoError(lexer.SetDefaultLanguage("en"))
+	lex := lexer.NewReaderLexer(strings.NewReader("function si"))
+	l.Assert().Equal(lexer.FUNCTION, lex.NextToken().Token_type)
+	l.Assert().Equal(lexer.IDENT, lex.NextToken().Token_type)
+
+	// the directive of the file wins over the default language
+	lex = lexer.NewLexer("// idioma: es\nfuncion")
+	l.Assert().Equal(lexer.FUNCTION, lex.NextToken().Token_type)
+
+	err := lexer.SetDefaultLanguage("fr")
+	l.Assert().EqualError(err, "idioma desconocido fr, los idiomas son en, es, pt")
+}
+
 func TestLexerSuite(t *testing.T) {
 	suite.Run(t, new(LexerTests))
 }
+
+// a program that uses most of the tokens, it is repeated to build sources
+// of different sizes
+const benchmarkProgram = `
+/// Calcula el año siguiente.
+funcion siguiente(año) {
+	/* un comentario
+	   de varias lineas */
+	si (año >= 2_000 && año != 0x7FF) {
+		regresa año + 1; // comentario
+	} si_no {
+		regresa f"canción {año * 2.5e3}";
+	}
+}
+
+var números = lista[1, 2, 3, "ñandú", 'acción\n'];
+`
+
+// the time per byte should not change with the size of the source
+func BenchmarkLexer(b *testing.B) {
+	for _, repetitions := range []int{10, 100, 1000} {
+		source := strings.Repeat(benchmarkProgram, repetitions)
+		b.Run(fmt.Sprintf("%dKB", len(source)/1024), func(b *testing.B) {
+			b.SetBytes(int64(len(source)))
+			for i := 0; i < b.N; i++ {
+				lex := lexer.NewLexer(source)
+				for token := lex.NextToken(); token.Token_type != lexer.EOF; token = lex.NextToken() {
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkReaderLexer(b *testing.B) {
+	source := strings.Repeat(benchmarkProgram, 1000)
+	b.SetBytes(int64(len(source)))
+	for i := 0; i < b.N; i++ {
+		lex := lexer.NewReaderLexer(strings.NewReader(source))
+		for token := lex.NextToken(); token.Token_type != lexer.EOF; token = lex.NextToken() {
+		}
+	}
+}
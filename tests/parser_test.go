@@ -6,7 +6,9 @@ import (
 	"aura/src/parser"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -73,6 +75,18 @@ func (p *ParserTests) TestLetStatements() {
 	}
 }
 
+func (p *ParserTests) TestConstStatement() {
+	source := "const limite = 10;"
+	_, program := p.InitParserTests(source)
+	p.Assert().Equal(1, len(program.Staments))
+
+	statement := program.Staments[0].(*ast.LetStatement)
+	p.Assert().Equal("const", statement.TokenLiteral())
+	p.testIdentifier(statement.Name, "limite")
+	p.testLiteralExpression(statement.Value, 10)
+	p.Assert().Equal("const limite = 10;", program.Str())
+}
+
 func (p *ParserTests) TestNamesInLetStatements() {
 	source := `
 		var x = 5;
@@ -111,6 +125,49 @@ func (p *ParserTests) TestParseErrors() {
 	}
 }
 
+func (p *ParserTests) TestLexerErrors() {
+	parser, _ := p.InitParserTests(`x := 1; y := "abc;`)
+	p.Assert().Equal([]string{"texto sin terminar en la linea 1, columna 14"}, parser.Errors())
+}
+
+func (p *ParserTests) TestParseFromReader() {
+	source := `
+		var año = 5;
+		funcion doble(x) { regresa x * 2; }
+		escribir(f"{doble(año)} años");
+		y := "sin cerrar;
+	`
+
+	expectedParser, expected := p.InitParserTests(source)
+	parser := parser.NewParser(l.NewReaderLexer(iotest.HalfReader(strings.NewReader(source))))
+	program := parser.ParseProgam()
+
+	p.Assert().Equal(expected.Str(), program.Str())
+	p.Assert().Equal(expectedParser.Errors(), parser.Errors())
+	p.Assert().NotEmpty(parser.Errors())
+}
+
+func (p *ParserTests) TestKeywordLanguages() {
+	english, expected := p.InitParserTests(`
+		// idioma: en
+		function double(x) { return x * 2; }
+		if (double(1) > 0) { f"{double(1)} {null}"; } else { for(i in list[1, 2]) { break; } }
+	`)
+
+	spanish, program := p.InitParserTests(`
+		funcion double(x) { regresa x * 2; }
+		si (double(1) > 0) { f"{double(1)} {nulo}"; } si_no { por(i en lista[1, 2]) { romper; } }
+	`)
+
+	p.Assert().Empty(english.Errors())
+	p.Assert().Empty(spanish.Errors())
+	p.Assert().Equal(program.Str(), expected.Str())
+
+	// the errors name the reserved words of the language of the file
+	parser, _ := p.InitParserTests("// idioma: en\nfor(i list[1]) {}")
+	p.Assert().Contains(parser.Errors(), "se esperaba que el siguient token fuera in pero se obtuvo list")
+}
+
 func (p *ParserTests) TestReturnStatement() {
 	source := `
 		regresa 5;
@@ -207,6 +264,30 @@ func (p *ParserTests) TestClassStatement() {
 	p.Assert().Equal(1, len(class.Methods))
 }
 
+func (p *ParserTests) TestDocComments() {
+	source := `
+		/// Suma dos numeros.
+		funcion suma(x, y) { x + y }
+
+		/// Una persona.
+		clase persona(nombre) {
+			/// Saluda a alguien.
+			saludar() => nombre;
+			despedir() => nombre;
+		}
+	`
+	parser, program := p.InitParserTests(source)
+	p.testProgramStatements(parser, program, 2)
+
+	function := (program.Staments[0].(*ast.ExpressionStament)).Expression.(*ast.Function)
+	p.Assert().Equal("Suma dos numeros.", function.Doc)
+
+	class := program.Staments[1].(*ast.ClassStatement)
+	p.Assert().Equal("Una persona.", class.Doc)
+	p.Assert().Equal("Saluda a alguien.", class.Methods[0].Doc)
+	p.Assert().Equal("", class.Methods[1].Doc)
+}
+
 func (p *ParserTests) TestClassCall() {
 	source := `
 		var p = nuevo Persona("joao", "informatica");
@@ -464,6 +545,57 @@ func (p *ParserTests) TestBooleanExpressions() {
 	}
 }
 
+func (p *ParserTests) TestSliceExpression() {
+	tests := []tuple[string]{
+		{"l[1:3];", "l[1:3]"},
+		{"l[:3];", "l[:3]"},
+		{"l[1:];", "l[1:]"},
+		{"l[:];", "l[:]"},
+		{"l[::2];", "l[::2]"},
+		{"l[a + 1:-1:-1];", "l[(a + 1):(- 1):(- 1)]"},
+		{"l[(1):2];", "l[1:2]"},
+		{"l[f(1, 2):2];", "l[f(1, 2):2]"},
+	}
+
+	for _, test := range tests {
+		parser, program := p.InitParserTests(test.source)
+		p.testProgramStatements(parser, program, 1)
+
+		call := (program.Staments[0].(*ast.ExpressionStament)).Expression.(*ast.CallList)
+		p.Assert().IsType(&ast.Slice{}, call.Index)
+		p.Assert().Equal(test.expected, program.Str())
+	}
+}
+
+func (p *ParserTests) TestComprehensionExpression() {
+	tests := []tuple[string]{
+		{"lista[x * 2 por x en datos];", "lista[(x * 2) por x en datos]"},
+		{"lista[x por x en datos si x > 0];", "lista[x por x en datos si (x > 0)]"},
+		{"mapa{k => v por k, v en pares};", "mapa{k => v por k, v en pares}"},
+	}
+
+	for _, test := range tests {
+		parser, program := p.InitParserTests(test.source)
+		p.testProgramStatements(parser, program, 1)
+
+		comprehension := (program.Staments[0].(*ast.ExpressionStament)).Expression
+		p.Assert().IsType(&ast.Comprehension{}, comprehension)
+		p.Assert().Equal(test.expected, program.Str())
+	}
+}
+
+func (p *ParserTests) TestSetExpression() {
+	source := "conjunto{1, 2, 3}"
+	parser, program := p.InitParserTests(source)
+	p.testProgramStatements(parser, program, 1)
+
+	set := (program.Staments[0].(*ast.ExpressionStament)).Expression.(*ast.SetExpression)
+	p.Assert().Equal(3, len(set.Values))
+	p.testInteger(set.Values[0], 1)
+	p.testInteger(set.Values[1], 2)
+	p.testInteger(set.Values[2], 3)
+}
+
 func (p *ParserTests) TestOperatorPrecedence() {
 	type TupleToTest struct {
 		source        string
@@ -482,7 +614,10 @@ func (p *ParserTests) TestOperatorPrecedence() {
 		{source: "-(5 + 5);", expected: "(- (5 + 5))", expectedCount: 1},
 		{source: "-(5 + 5);", expected: "(- (5 + 5))", expectedCount: 1},
 		{source: "a + suma(b * c) + d;", expected: "((a + suma((b * c))) + d)", expectedCount: 1},
-		{source: "a + suma(b * c) + d;", expected: "((a + suma((b * c))) + d)", expectedCount: 1},
+		{source: "a | b & c == d;", expected: "(((a | b) & c) == d)", expectedCount: 1},
+		{source: "a - b | c;", expected: "((a - b) | c)", expectedCount: 1},
+		{source: "2 ** 3 * 4;", expected: "((2 ** 3) * 4)", expectedCount: 1},
+		{source: "a + b ** c;", expected: "(a + (b ** c))", expectedCount: 1},
 		{
 			source:        "suma(a, b, 1, 2 * 3, 4 + 5, suma(6, 7 * 8))",
 			expected:      "suma(a, b, 1, (2 * 3), (4 + 5), suma(6, (7 * 8)))",
@@ -498,6 +633,59 @@ func (p *ParserTests) TestOperatorPrecedence() {
 	}
 }
 
+func (p *ParserTests) TestNumberLiterals() {
+	tests := []tuple[string]{
+		{"0xFF;", "255"},
+		{"0b1010;", "10"},
+		{"0o17;", "15"},
+		{"007;", "7"},
+		{"1_000_000;", "1000000"},
+		{"0xFFFF_FFFF_FFFF_FFFF_FF;", "4722366482869645213695"},
+	}
+
+	for _, test := range tests {
+		parser, program := p.InitParserTests(test.source)
+		p.testProgramStatements(parser, program, 1)
+		p.Assert().Equal(test.expected, program.Str())
+	}
+
+	errors := []tuple[string]{
+		{"0b102;", "literal entero no valido 0b102 en la linea 1, columna 1"},
+		{"x := 1__0;", "literal entero no valido 1__0 en la linea 1, columna 6"},
+		{"x := 1;\ny := 1e400;", "el flotante 1e400 esta fuera de rango en la linea 2, columna 6"},
+		{"1e-400;", "el flotante 1e-400 esta fuera de rango en la linea 1, columna 1"},
+		{"1_.5;", "literal flotante no valido 1_.5 en la linea 1, columna 1"},
+	}
+
+	for _, test := range errors {
+		parser, _ := p.InitParserTests(test.source)
+		p.Assert().Contains(parser.Errors(), test.expected)
+	}
+}
+
+func (p *ParserTests) TestInterpolatedString() {
+	parser, program := p.InitParserTests(`f"Hola {nombre}, {edad + 1}!";`)
+	p.testProgramStatements(parser, program, 1)
+
+	interpolated := program.Staments[0].(*ast.ExpressionStament).Expression.(*ast.InterpolatedString)
+	p.Assert().Equal(5, len(interpolated.Parts))
+	p.Assert().IsType(&ast.StringLiteral{}, interpolated.Parts[0])
+	p.Assert().IsType(&ast.Identifier{}, interpolated.Parts[1])
+	p.Assert().IsType(&ast.Infix{}, interpolated.Parts[3])
+	p.Assert().Equal(`f"Hola {nombre}, {(edad + 1)}!"`, program.Str())
+
+	tests := []tuple[string]{
+		{`f"{}";`, "se esperaba una expresion en el texto interpolado: {}"},
+		{`f"{var x = 1}";`, "se esperaba una expresion en el texto interpolado: {var x = 1}"},
+		{`f"{)}";`, "en el texto interpolado: no se encontro ninguna funcion para parsear )"},
+	}
+
+	for _, test := range tests {
+		parser, _ := p.InitParserTests(test.source)
+		p.Assert().Contains(parser.Errors(), test.expected)
+	}
+}
+
 func (p *ParserTests) TestStringLiteral() {
 	source := `"hello world!";`
 